@@ -1 +1,32 @@
 package main
+
+import (
+	"errors"
+	"github.com/joho/godotenv"
+	"io/fs"
+	"log/slog"
+	"os"
+	"zadanie-6105/cmd/app/internal/config"
+	"zadanie-6105/cmd/app/internal/logging"
+)
+
+const defaultConfigPath = "configs/app/default.yml"
+
+// loadConfig подгружает .env в окружение и читает конфигурацию из CONFIG_PATH или файла по умолчанию
+func loadConfig() config.Config {
+	// .env нужен только для локального запуска, в окружении оркестратора переменные задаются напрямую
+	if err := godotenv.Load(".env"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("Не удалось загрузить .env файл, используются переменные окружения", "error", err)
+	}
+
+	path := os.Getenv("CONFIG_PATH")
+	if path == "" {
+		path = defaultConfigPath
+	}
+
+	conf, err := config.Load(path)
+	if err != nil {
+		logging.Fatal("Ошибка загрузки конфигурации", err)
+	}
+	return conf
+}
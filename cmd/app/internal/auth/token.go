@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+const (
+	defaultTokenTTL = 24 * time.Hour
+	// MinSecretLength - минимальная длина ключа подписи HS256 в байтах
+	MinSecretLength = 32
+)
+
+var (
+	ErrInvalidToken  = errors.New("invalid token")
+	ErrSecretMissing = errors.New("token secret is required outside legacy mode")
+)
+
+type Config struct {
+	// Secret - ключ подписи HS256, общий для всех экземпляров. Обязателен вне режима совместимости,
+	// в режиме совместимости без него ключ генерируется при старте и токены не переживают рестарт
+	Secret string `yaml:"secret" env:"AUTH_SECRET"`
+	// IssuerKey - ключ, который должен передать клиент для получения токена. Обязателен вне режима совместимости,
+	// в режиме совместимости пустой ключ отключает проверку
+	IssuerKey string        `yaml:"issuer_key" env:"AUTH_ISSUER_KEY"`
+	TokenTTL  time.Duration `yaml:"token_ttl" env:"AUTH_TOKEN_TTL" env-default:"24h"`
+	// Legacy - режим совместимости, в котором запросы без токена идентифицируются по параметру username
+	Legacy bool `yaml:"legacy" env:"AUTH_LEGACY"`
+}
+
+type Claims struct {
+	Username string `json:"username"`
+	jwt.RegisteredClaims
+}
+
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokenManager(conf Config) (*TokenManager, error) {
+	secret := []byte(conf.Secret)
+	if len(secret) == 0 {
+		if !conf.Legacy {
+			return nil, ErrSecretMissing
+		}
+		secret = make([]byte, MinSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("generate token secret: %w", err)
+		}
+	}
+
+	ttl := conf.TokenTTL
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+
+	return &TokenManager{secret: secret, ttl: ttl}, nil
+}
+
+// Issue выпускает подписанный токен для пользователя и возвращает время его истечения
+func (m *TokenManager) Issue(username string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	claims := Claims{
+		Username: username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   username,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("sign token: %w", err)
+	}
+
+	return token, expiresAt, nil
+}
+
+// Parse проверяет подпись и срок действия токена и возвращает имя пользователя
+func (m *TokenManager) Parse(tokenString string) (string, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if claims.Username == "" {
+		return "", ErrInvalidToken
+	}
+
+	return claims.Username, nil
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ilyakaznacheev/cleanenv"
+	"gorm.io/gorm/logger"
+	"log/slog"
+	"os"
+	"time"
+	"zadanie-6105/cmd/app/internal/auth"
+	"zadanie-6105/cmd/app/internal/logging"
+	"zadanie-6105/cmd/app/internal/storage/postgresql"
+)
+
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+// Config - настройки приложения. Значения читаются из YAML-файла, переменные окружения имеют приоритет
+type Config struct {
+	Server    Server                `yaml:"server"`
+	Storage   Storage               `yaml:"storage"`
+	Database  postgresql.Config     `yaml:"database"`
+	Pool      postgresql.PoolConfig `yaml:"pool"`
+	Log       Log                   `yaml:"log"`
+	Auth      auth.Config           `yaml:"auth"`
+	Scheduler Scheduler             `yaml:"scheduler"`
+}
+
+type Server struct {
+	Address      string        `yaml:"address" env:"SERVER_ADDRESS" env-default:":8080"`
+	ReadTimeout  time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	// ShutdownTimeout - сколько ждать завершения текущих запросов и фоновых задач при остановке
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" env-default:"15s"`
+	// ReadinessTimeout - общий таймаут проверок зависимостей в /readyz
+	ReadinessTimeout time.Duration `yaml:"readiness_timeout" env:"SERVER_READINESS_TIMEOUT" env-default:"2s"`
+}
+
+type Storage struct {
+	// Type - postgres или memory
+	Type string `yaml:"type" env:"STORAGE" env-default:"postgres"`
+	// Seed - необязательный JSON с начальными данными для хранилища в памяти
+	Seed string `yaml:"seed" env:"STORAGE_SEED"`
+}
+
+type Scheduler struct {
+	// Interval - как часто запускаются фоновые задачи, например закрытие тендеров по сроку. 0 отключает планировщик
+	Interval time.Duration `yaml:"interval" env:"SCHEDULER_INTERVAL" env-default:"30s"`
+}
+
+type Log struct {
+	// Level - минимальный уровень логов приложения: debug, info, warn или error
+	Level string `yaml:"level" env:"LOG_LEVEL" env-default:"info"`
+	// Format - json или text
+	Format string `yaml:"format" env:"LOG_FORMAT" env-default:"json"`
+	// SQLLevel - уровень логирования запросов gorm: silent, error, warn или info
+	SQLLevel string `yaml:"sql_level" env:"LOG_SQL_LEVEL" env-default:"warn"`
+	// SQLSlowThreshold - запросы дольше этого времени пишутся с уровнем warn, 0 отключает проверку
+	SQLSlowThreshold time.Duration `yaml:"sql_slow_threshold" env:"LOG_SQL_SLOW_THRESHOLD" env-default:"200ms"`
+}
+
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+var sqlLogLevels = map[string]logger.LogLevel{
+	"silent": logger.Silent,
+	"error":  logger.Error,
+	"warn":   logger.Warn,
+	"info":   logger.Info,
+}
+
+// Load читает конфигурацию из path и переменных окружения и проверяет ее.
+// Если файла нет, используются только переменные окружения и значения по умолчанию
+func Load(path string) (Config, error) {
+	var conf Config
+
+	var err error
+	if _, statErr := os.Stat(path); statErr == nil {
+		err = cleanenv.ReadConfig(path, &conf)
+	} else {
+		err = cleanenv.ReadEnv(&conf)
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("read config: %w", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return conf, nil
+}
+
+// Validate проверяет значения, без которых приложение не сможет запуститься
+func (c Config) Validate() error {
+	var errs []error
+
+	if c.Server.Address == "" {
+		errs = append(errs, errors.New("server.address is required"))
+	}
+	if c.Server.ReadTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 {
+		errs = append(errs, errors.New("server timeouts must not be negative"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be positive"))
+	}
+	if c.Server.ReadinessTimeout <= 0 {
+		errs = append(errs, errors.New("server.readiness_timeout must be positive"))
+	}
+
+	switch c.Storage.Type {
+	case StoragePostgres:
+		if c.Database.Host == "" {
+			errs = append(errs, errors.New("database.host is required"))
+		}
+		if c.Database.User == "" {
+			errs = append(errs, errors.New("database.user is required"))
+		}
+		if c.Database.Database == "" {
+			errs = append(errs, errors.New("database.database is required"))
+		}
+		if c.Database.Port < 0 || c.Database.Port > 65535 {
+			errs = append(errs, fmt.Errorf("database.port %d is out of range", c.Database.Port))
+		}
+	case StorageMemory:
+	default:
+		errs = append(errs, fmt.Errorf("unknown storage.type %q", c.Storage.Type))
+	}
+
+	if c.Pool.MaxOpenConns < 0 || c.Pool.MaxIdleConns < 0 || c.Pool.ConnMaxLifetime < 0 || c.Pool.ConnMaxIdleTime < 0 {
+		errs = append(errs, errors.New("pool settings must not be negative"))
+	}
+	if c.Pool.MaxOpenConns > 0 && c.Pool.MaxIdleConns > c.Pool.MaxOpenConns {
+		errs = append(errs, errors.New("pool.max_idle_conns must not exceed pool.max_open_conns"))
+	}
+
+	if _, ok := logLevels[c.Log.Level]; !ok {
+		errs = append(errs, fmt.Errorf("unknown log.level %q", c.Log.Level))
+	}
+	if c.Log.Format != logging.FormatJSON && c.Log.Format != logging.FormatText {
+		errs = append(errs, fmt.Errorf("unknown log.format %q", c.Log.Format))
+	}
+	if _, ok := sqlLogLevels[c.Log.SQLLevel]; !ok {
+		errs = append(errs, fmt.Errorf("unknown log.sql_level %q", c.Log.SQLLevel))
+	}
+	if c.Log.SQLSlowThreshold < 0 {
+		errs = append(errs, errors.New("log.sql_slow_threshold must not be negative"))
+	}
+
+	if c.Auth.TokenTTL <= 0 {
+		errs = append(errs, errors.New("auth.token_ttl must be positive"))
+	}
+	// Вне режима совместимости токен - единственный способ представиться, поэтому его выпуск должен быть защищен,
+	// а подпись - одинаковой на всех экземплярах и после рестарта
+	if !c.Auth.Legacy {
+		if c.Auth.Secret == "" {
+			errs = append(errs, errors.New("auth.secret is required when auth.legacy is false"))
+		} else if len(c.Auth.Secret) < auth.MinSecretLength {
+			errs = append(errs, fmt.Errorf("auth.secret must be at least %d bytes", auth.MinSecretLength))
+		}
+		if c.Auth.IssuerKey == "" {
+			errs = append(errs, errors.New("auth.issuer_key is required when auth.legacy is false"))
+		}
+	}
+
+	if c.Scheduler.Interval < 0 {
+		errs = append(errs, errors.New("scheduler.interval must not be negative"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// SlogLevel возвращает уровень slog, соответствующий log.level
+func (l Log) SlogLevel() slog.Level {
+	return logLevels[l.Level]
+}
+
+// SQLLogLevel возвращает уровень логирования gorm, соответствующий log.sql_level
+func (l Log) SQLLogLevel() logger.LogLevel {
+	return sqlLogLevels[l.SQLLevel]
+}
@@ -0,0 +1,112 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// Manager запускает долгоживущие компоненты приложения и останавливает их по сигналу.
+// Компоненты, запущенные через Go, получают контекст, который отменяется при остановке,
+// после их завершения в обратном порядке выполняются хуки OnShutdown (например, закрытие пула соединений)
+type Manager struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	stopSignals context.CancelFunc
+
+	workers sync.WaitGroup
+
+	mu    sync.Mutex
+	err   error
+	hooks []hook
+}
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// New создает менеджер, который начинает остановку при получении одного из signals
+func New(signals ...os.Signal) *Manager {
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), signals...)
+	ctx, cancel := context.WithCancel(signalCtx)
+	return &Manager{
+		ctx:         ctx,
+		cancel:      cancel,
+		stopSignals: stopSignals,
+	}
+}
+
+// Context отменяется, когда приложение начинает остановку
+func (m *Manager) Context() context.Context {
+	return m.ctx
+}
+
+// Go запускает компонент в отдельной горутине. run должен вернуться после отмены ctx.
+// Ошибка компонента, кроме context.Canceled, останавливает все приложение
+func (m *Manager) Go(name string, run func(ctx context.Context) error) {
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		if err := run(m.ctx); err != nil && !errors.Is(err, context.Canceled) {
+			m.fail(fmt.Errorf("%s: %w", name, err))
+		}
+	}()
+}
+
+// OnShutdown регистрирует хук, который выполнится после остановки компонентов.
+// Хуки выполняются в порядке, обратном регистрации
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// Wait блокируется до сигнала или ошибки компонента, затем ждет завершения компонентов не дольше timeout
+// и выполняет хуки. Возвращает ошибку компонента, из-за которой началась остановка, и ошибки хуков
+func (m *Manager) Wait(timeout time.Duration) error {
+	<-m.ctx.Done()
+	// Повторный сигнал завершает процесс сразу, не дожидаясь корректной остановки
+	m.stopSignals()
+	m.cancel()
+	slog.Info("Остановка приложения")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		slog.Warn("Не все компоненты остановились вовремя", "timeout", timeout.String())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	errs := []error{m.err}
+	for i := len(m.hooks) - 1; i >= 0; i-- {
+		if err := m.hooks[i].fn(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.hooks[i].name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) fail(err error) {
+	m.mu.Lock()
+	if m.err == nil {
+		m.err = err
+	}
+	m.mu.Unlock()
+	m.cancel()
+}
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"time"
+)
+
+// GormLogger пишет запросы gorm через логгер из контекста, поэтому SQL попадает в лог с request_id запроса.
+// Запросы дольше SlowThreshold пишутся с уровнем warn
+type GormLogger struct {
+	Level         logger.LogLevel
+	SlowThreshold time.Duration
+}
+
+func NewGormLogger(level logger.LogLevel, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{Level: level, SlowThreshold: slowThreshold}
+}
+
+func (l *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.Level = level
+	return &copied
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...any) {
+	if l.Level >= logger.Info {
+		FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.Level >= logger.Warn {
+		FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...any) {
+	if l.Level >= logger.Error {
+		FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.Level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	isSlow := l.SlowThreshold > 0 && elapsed > l.SlowThreshold
+	// Отсутствие записи - штатная ситуация, сервисы превращают ее в 404
+	isFailed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+
+	switch {
+	case isFailed && l.Level >= logger.Error:
+	case isSlow && l.Level >= logger.Warn:
+	case l.Level >= logger.Info:
+	default:
+		return
+	}
+
+	sql, rows := fc()
+	log := FromContext(ctx).With(
+		"sql", sql,
+		"rows", rows,
+		"duration_ms", float64(elapsed.Microseconds())/1000,
+	)
+
+	switch {
+	case isFailed:
+		log.ErrorContext(ctx, "Ошибка SQL-запроса", "error", err)
+	case isSlow:
+		log.WarnContext(ctx, "Медленный SQL-запрос", "slow_threshold_ms", l.SlowThreshold.Milliseconds())
+	default:
+		log.InfoContext(ctx, "SQL-запрос")
+	}
+}
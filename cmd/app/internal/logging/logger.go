@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type loggerKey struct{}
+
+// New создает логгер, который пишет в w в формате json или text начиная с уровня level
+func New(w io.Writer, format string, level slog.Level) *slog.Logger {
+	options := &slog.HandlerOptions{Level: level}
+	if format == FormatText {
+		return slog.New(slog.NewTextHandler(w, options))
+	}
+	return slog.New(slog.NewJSONHandler(w, options))
+}
+
+// Setup делает логгер логгером по умолчанию. Вызовы пакета log после этого тоже проходят через него
+func Setup(format string, level slog.Level) *slog.Logger {
+	logger := New(os.Stdout, format, level)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// WithLogger сохраняет логгер запроса в контексте, чтобы сервисы и хранилище писали с теми же атрибутами
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext возвращает логгер запроса или логгер по умолчанию
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// Fatal пишет ошибку и завершает процесс, как log.Fatal
+func Fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
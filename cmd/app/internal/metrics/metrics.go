@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"strconv"
+	"strings"
+	"time"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+const namespace = "tender"
+
+// unmatchedRoute - метка для запросов, не дошедших ни до одного маршрута, чтобы произвольные пути не раздували число рядов
+const unmatchedRoute = "unmatched"
+
+// Metrics хранит собственный реестр Prometheus с HTTP-метриками, статистикой пула соединений и бизнес-счетчиками
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+
+	tendersCreated   prometheus.Counter
+	tendersPublished prometheus.Counter
+	bidsSubmitted    prometheus.Counter
+	bidDecisions     *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by route, method and status code.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		tendersCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tenders_created_total",
+			Help:      "Number of created tenders.",
+		}),
+		tendersPublished: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tenders_published_total",
+			Help:      "Number of tender publications.",
+		}),
+		bidsSubmitted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bids_submitted_total",
+			Help:      "Number of submitted bids.",
+		}),
+		bidDecisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bid_decisions_total",
+			Help:      "Number of bid decisions by decision type.",
+		}, []string{"decision"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.duration,
+		m.tendersCreated,
+		m.tendersPublished,
+		m.bidsSubmitted,
+		m.bidDecisions,
+	)
+
+	return m
+}
+
+// RegisterDB добавляет статистику пула соединений *sql.DB
+func (m *Metrics) RegisterDB(db *sql.DB) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+// Handler отдает метрики в текстовом формате Prometheus
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+// Middleware считает запросы и их длительность по шаблону маршрута, а не по фактическому пути
+func (m *Metrics) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		startedAt := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// Необработанную ошибку в ответ превратит ErrorHandler уже после middleware, поэтому код берется из нее
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		route := c.Route().Path
+		if status == fiber.StatusNotFound && !strings.Contains(route, ":") && route != c.Path() {
+			route = unmatchedRoute
+		}
+
+		method := c.Method()
+		m.requests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+		m.duration.WithLabelValues(method, route).Observe(time.Since(startedAt).Seconds())
+
+		return err
+	}
+}
+
+func (m *Metrics) TenderCreated() {
+	m.tendersCreated.Inc()
+}
+
+func (m *Metrics) TenderPublished() {
+	m.tendersPublished.Inc()
+}
+
+func (m *Metrics) BidSubmitted() {
+	m.bidsSubmitted.Inc()
+}
+
+func (m *Metrics) BidDecisionMade(decision models2.BidDecisionType) {
+	m.bidDecisions.WithLabelValues(strings.ToLower(string(decision))).Inc()
+}
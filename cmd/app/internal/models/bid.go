@@ -11,17 +11,21 @@ const (
 	BidStatusCreated   BidStatusType = "CREATED"
 	BidStatusPublished BidStatusType = "PUBLISHED"
 	BidStatusCanceled  BidStatusType = "CANCELED"
+	BidStatusApproved  BidStatusType = "APPROVED"
+	BidStatusRejected  BidStatusType = "REJECTED"
 )
 
 type Bid struct {
 	ID              uuid.UUID     `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
 	Name            string        `gorm:"type:varchar(255);not null" json:"name"`
 	Description     string        `gorm:"type:text" json:"description"`
-	Status          BidStatusType `gorm:"type:varchar(20);not null;default:'Created'" json:"status"`
+	Status          BidStatusType `gorm:"type:varchar(20);not null;default:'CREATED'" json:"status"`
 	TenderID        uuid.UUID     `gorm:"type:uuid;not null" json:"tenderId"`
 	OrganizationID  uuid.UUID     `gorm:"type:uuid;not null" json:"organizationId"`
 	Version         int           `gorm:"default:1" json:"version"`
 	CreatorUsername string        `json:"creatorUsername" validate:"required"`
 	CreatedAt       time.Time     `gorm:"default:current_timestamp" json:"createdAt"`
 	UpdatedAt       time.Time     `gorm:"default:current_timestamp" json:"updatedAt"`
+	// Price - цена предложения, пустая валюта означает, что цена не указана
+	Price Money `gorm:"embedded;embeddedPrefix:price_" json:"price"`
 }
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type BidDecisionType string
+
+const (
+	BidDecisionApproved BidDecisionType = "APPROVED"
+	BidDecisionRejected BidDecisionType = "REJECTED"
+)
+
+type BidDecision struct {
+	ID        uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BidID     uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_bid_decision_bid_user" json:"bidId"`
+	UserID    uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_bid_decision_bid_user" json:"userId"`
+	Decision  BidDecisionType `gorm:"type:varchar(20);not null" json:"decision"`
+	CreatedAt time.Time       `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time       `gorm:"autoUpdateTime" json:"updatedAt"`
+}
@@ -13,4 +13,6 @@ type BidVersion struct {
 	Description string        `gorm:"type:text" json:"description"`
 	Status      BidStatusType `gorm:"type:varchar(20);not null" json:"status"`
 	CreatedAt   time.Time     `gorm:"default:current_timestamp" json:"createdAt"`
+	// Price - цена предложения в этой версии
+	Price Money `gorm:"embedded;embeddedPrefix:price_" json:"price"`
 }
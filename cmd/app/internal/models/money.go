@@ -0,0 +1,30 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// Money - денежная сумма в валюте ISO 4217. Сумма хранится как точное десятичное число, а не float,
+// и в JSON передается строкой, чтобы клиенты не теряли точность.
+// Колонки не допускают NULL: у незаданной суммы пустая валюта, см. IsZero
+type Money struct {
+	Amount      decimal.Decimal `gorm:"type:numeric(20,4);not null" json:"amount"`
+	Currency    string          `gorm:"type:varchar(3);not null" json:"currency"`
+	VATIncluded bool            `gorm:"not null" json:"vatIncluded"`
+}
+
+// IsZero сообщает, что сумма не задана: у заданной суммы всегда есть валюта
+func (m Money) IsZero() bool {
+	return m.Currency == ""
+}
+
+// Equal сравнивает суммы по значению, 100 и 100.00 считаются равными
+func (m Money) Equal(other Money) bool {
+	return m.Amount.Equal(other.Amount) && m.Currency == other.Currency && m.VATIncluded == other.VATIncluded
+}
+
+// Ptr возвращает nil для незаданной суммы, чтобы поле не попадало в ответ
+func (m Money) Ptr() *Money {
+	if m.IsZero() {
+		return nil
+	}
+	return &m
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Статусы и решения хранятся в базе в верхнем регистре, а в API передаются так, как они записаны в спецификации.
+// Типы реализуют encoding.TextMarshaler и encoding.TextUnmarshaler, поэтому JSON всегда содержит написание
+// из спецификации, а на входе принимается любой регистр.
+// Метод String намеренно не определен: pgx кодирует fmt.Stringer через него, и в базу попало бы написание из спецификации
+
+var tenderStatusNames = map[TenderStatusType]string{
+	TenderStatusCreated:   "Created",
+	TenderStatusPublished: "Published",
+	TenderStatusClosed:    "Closed",
+}
+
+var bidStatusNames = map[BidStatusType]string{
+	BidStatusCreated:   "Created",
+	BidStatusPublished: "Published",
+	BidStatusCanceled:  "Canceled",
+	BidStatusApproved:  "Approved",
+	BidStatusRejected:  "Rejected",
+}
+
+var bidDecisionNames = map[BidDecisionType]string{
+	BidDecisionApproved: "Approved",
+	BidDecisionRejected: "Rejected",
+}
+
+// ParseTenderStatus разбирает статус тендера в любом регистре
+func ParseTenderStatus(value string) (TenderStatusType, error) {
+	return parseEnum(value, tenderStatusNames, "tender status")
+}
+
+// ParseBidStatus разбирает статус предложения в любом регистре
+func ParseBidStatus(value string) (BidStatusType, error) {
+	return parseEnum(value, bidStatusNames, "bid status")
+}
+
+// ParseBidDecision разбирает решение по предложению в любом регистре
+func ParseBidDecision(value string) (BidDecisionType, error) {
+	return parseEnum(value, bidDecisionNames, "bid decision")
+}
+
+func (s TenderStatusType) MarshalText() ([]byte, error) {
+	return marshalEnum(s, tenderStatusNames), nil
+}
+
+func (s *TenderStatusType) UnmarshalText(text []byte) (err error) {
+	*s, err = ParseTenderStatus(string(text))
+	return err
+}
+
+func (s BidStatusType) MarshalText() ([]byte, error) {
+	return marshalEnum(s, bidStatusNames), nil
+}
+
+func (s *BidStatusType) UnmarshalText(text []byte) (err error) {
+	*s, err = ParseBidStatus(string(text))
+	return err
+}
+
+func (d BidDecisionType) MarshalText() ([]byte, error) {
+	return marshalEnum(d, bidDecisionNames), nil
+}
+
+func (d *BidDecisionType) UnmarshalText(text []byte) (err error) {
+	*d, err = ParseBidDecision(string(text))
+	return err
+}
+
+func parseEnum[T ~string](value string, names map[T]string, kind string) (T, error) {
+	normalized := T(strings.ToUpper(strings.TrimSpace(value)))
+	if _, ok := names[normalized]; !ok {
+		return "", fmt.Errorf("unknown %s %q", kind, value)
+	}
+	return normalized, nil
+}
+
+// marshalEnum отдает написание из спецификации, неизвестное значение (например, пустое) отдается как есть
+func marshalEnum[T ~string](value T, names map[T]string) []byte {
+	if name, ok := names[value]; ok {
+		return []byte(name)
+	}
+	return []byte(value)
+}
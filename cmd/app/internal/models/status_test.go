@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseTenderStatus(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    TenderStatusType
+		wantErr bool
+	}{
+		{value: "Created", want: TenderStatusCreated},
+		{value: "published", want: TenderStatusPublished},
+		{value: "CLOSED", want: TenderStatusClosed},
+		{value: " Published ", want: TenderStatusPublished},
+		{value: "Canceled", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTenderStatus(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Fatalf("ParseTenderStatus(%q) = %q, %v, want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseBidStatusAndDecision(t *testing.T) {
+	for _, value := range []string{"created", "Published", "CANCELED", "approved", "Rejected"} {
+		if _, err := ParseBidStatus(value); err != nil {
+			t.Errorf("ParseBidStatus(%q) error = %v", value, err)
+		}
+	}
+	if _, err := ParseBidStatus("Closed"); err == nil {
+		t.Error("ParseBidStatus(\"Closed\") error = nil, want unknown status")
+	}
+
+	for _, value := range []string{"approved", "REJECTED"} {
+		if _, err := ParseBidDecision(value); err != nil {
+			t.Errorf("ParseBidDecision(%q) error = %v", value, err)
+		}
+	}
+	// Решение - не любой статус предложения
+	if _, err := ParseBidDecision("Canceled"); err == nil {
+		t.Error("ParseBidDecision(\"Canceled\") error = nil, want unknown decision")
+	}
+}
+
+func TestStatusJSONRoundTrip(t *testing.T) {
+	type payload struct {
+		Tender   TenderStatusType `json:"tender"`
+		Bid      BidStatusType    `json:"bid"`
+		Decision BidDecisionType  `json:"decision"`
+	}
+
+	var decoded payload
+	if err := json.Unmarshal([]byte(`{"tender":"PUBLISHED","bid":"canceled","decision":"Approved"}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	want := payload{Tender: TenderStatusPublished, Bid: BidStatusCanceled, Decision: BidDecisionApproved}
+	if decoded != want {
+		t.Fatalf("decoded = %+v, want %+v", decoded, want)
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `{"tender":"Published","bid":"Canceled","decision":"Approved"}` {
+		t.Fatalf("encoded = %s, want statuses as written in the specification", encoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"tender":"Open"}`), &decoded); err == nil {
+		t.Fatal("Unmarshal() of unknown tender status error = nil")
+	}
+}
+
+func TestStatusStoredUppercase(t *testing.T) {
+	// В базу попадает значение типа, а не написание из спецификации
+	for status, name := range tenderStatusNames {
+		parsed, err := ParseTenderStatus(name)
+		if err != nil || parsed != status {
+			t.Errorf("ParseTenderStatus(%q) = %q, %v, want %q", name, parsed, err, status)
+		}
+	}
+	for status, name := range bidStatusNames {
+		parsed, err := ParseBidStatus(name)
+		if err != nil || parsed != status {
+			t.Errorf("ParseBidStatus(%q) = %q, %v, want %q", name, parsed, err, status)
+		}
+	}
+}
+
+func TestMarshalUnknownStatus(t *testing.T) {
+	text, err := TenderStatusType("").MarshalText()
+	if err != nil || string(text) != "" {
+		t.Fatalf("MarshalText() of empty status = %q, %v, want empty", text, err)
+	}
+}
@@ -13,15 +13,28 @@ const (
 	TenderStatusClosed    TenderStatusType = "CLOSED"
 )
 
+// Виды услуг из спецификации
+const (
+	ServiceTypeConstruction = "Construction"
+	ServiceTypeDelivery     = "Delivery"
+	ServiceTypeManufacture  = "Manufacture"
+)
+
 type Tender struct {
-	ID              uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id,omitempty" :"id"`
+	ID              uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id,omitempty"`
 	Name            string           `gorm:"type:varchar(100);not null"`
 	Description     string           `gorm:"type:text"`
-	ServiceType     string           `gorm:"type:varchar(100)" json:"serviceType,omitempty" :"service_type"`
-	Status          TenderStatusType `gorm:"type:varchar(20);not null;default:'CREATED'" json:"status,omitempty" :"status"`
-	OrganizationID  uuid.UUID        `gorm:"type:uuid;not null" json:"organizationId,omitempty" :"organization_id"`
-	CreatedAt       time.Time        `gorm:"autoCreateTime" json:"createdAt" :"created_at"`
-	UpdatedAt       time.Time        `gorm:"autoUpdateTime" json:"updated_at" :"updated_at"`
+	ServiceType     string           `gorm:"type:varchar(100)" json:"serviceType,omitempty"`
+	Status          TenderStatusType `gorm:"type:varchar(20);not null;default:'CREATED'" json:"status,omitempty"`
+	OrganizationID  uuid.UUID        `gorm:"type:uuid;not null" json:"organizationId,omitempty"`
+	CreatedAt       time.Time        `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt       time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
 	CreatorUsername string           `json:"creatorUsername" validate:"required"`
 	Version         int              `json:"version"`
+	// SubmissionDeadline - срок подачи предложений, nil означает, что срока нет
+	SubmissionDeadline *time.Time `gorm:"type:timestamptz" json:"submissionDeadline,omitempty"`
+	// PublishAt - время, когда созданный тендер будет опубликован автоматически, nil означает ручную публикацию
+	PublishAt *time.Time `gorm:"type:timestamptz" json:"publishAt,omitempty"`
+	// Budget - бюджет тендера, пустая валюта означает, что бюджет не указан
+	Budget Money `gorm:"embedded;embeddedPrefix:budget_" json:"budget"`
 }
@@ -6,12 +6,18 @@ import (
 )
 
 type TenderResponse struct {
-	ID             uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id,omitempty" :"id"`
+	ID             uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id,omitempty"`
 	Name           string           `gorm:"type:varchar(100);not null" json:"name,omitempty"`
 	Description    string           `gorm:"type:text" json:"description,omitempty"`
-	ServiceType    string           `gorm:"type:varchar(100)" json:"serviceType,omitempty" :"service_type"`
-	Status         TenderStatusType `gorm:"type:varchar(20);not null;default:'CREATED'" json:"status,omitempty" :"status"`
-	OrganizationID uuid.UUID        `gorm:"type:uuid;not null" json:"organizationId,omitempty" :"organization_id"`
-	CreatedAt      time.Time        `gorm:"autoCreateTime" json:"createdAt" :"created_at"`
+	ServiceType    string           `gorm:"type:varchar(100)" json:"serviceType,omitempty"`
+	Status         TenderStatusType `gorm:"type:varchar(20);not null;default:'CREATED'" json:"status,omitempty"`
+	OrganizationID uuid.UUID        `gorm:"type:uuid;not null" json:"organizationId,omitempty"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime" json:"createdAt"`
 	Version        int              `gorm:"type:int;not null" json:"version"`
+	// SubmissionDeadline - срок подачи предложений, не передается, если срока нет
+	SubmissionDeadline *time.Time `json:"submissionDeadline,omitempty"`
+	// PublishAt - время запланированной публикации, не передается, если публикация не запланирована
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+	// Budget - бюджет тендера, не передается, если не указан
+	Budget *Money `json:"budget,omitempty"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TenderTransition - запись в истории смены статусов тендера
+type TenderTransition struct {
+	ID            uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TenderID      uuid.UUID        `gorm:"type:uuid;not null" json:"tenderId"`
+	FromStatus    TenderStatusType `gorm:"type:varchar(20);not null" json:"from"`
+	ToStatus      TenderStatusType `gorm:"type:varchar(20);not null" json:"to"`
+	ActorUsername string           `gorm:"type:varchar(50);not null" json:"actorUsername"`
+	CreatedAt     time.Time        `gorm:"autoCreateTime" json:"createdAt"`
+}
@@ -6,12 +6,20 @@ import (
 )
 
 type TenderVersion struct {
-	ID          uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id,omitempty" :"id"`
+	ID          uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id,omitempty"`
 	Name        string           `gorm:"type:varchar(100);not null"`
 	Description string           `gorm:"type:text"`
-	ServiceType string           `gorm:"type:varchar(100)" json:"serviceType,omitempty" :"service_type"`
-	Status      TenderStatusType `gorm:"type:varchar(20);not null;default:'CREATED'" json:"status,omitempty" :"status"`
-	CreatedAt   time.Time        `gorm:"autoCreateTime" json:"createdAt" :"created_at"`
+	ServiceType string           `gorm:"type:varchar(100)" json:"serviceType,omitempty"`
+	Status      TenderStatusType `gorm:"type:varchar(20);not null;default:'CREATED'" json:"status,omitempty"`
+	CreatedAt   time.Time        `gorm:"autoCreateTime" json:"createdAt"`
 	TenderID    uuid.UUID        `gorm:"type:uuid;not null" json:"tenderId"`
 	Version     int              `gorm:"type:int;not null" json:"version"`
+	// SubmissionDeadline - срок подачи предложений в этой версии
+	SubmissionDeadline *time.Time `gorm:"type:timestamptz" json:"submissionDeadline,omitempty"`
+	// PublishAt - время запланированной публикации в этой версии
+	PublishAt *time.Time `gorm:"type:timestamptz" json:"publishAt,omitempty"`
+	// AuthorUsername - кто создал версию, для изменений планировщика - service.SystemActor
+	AuthorUsername string `gorm:"type:varchar(50);not null;default:''" json:"authorUsername,omitempty"`
+	// Budget - бюджет тендера в этой версии
+	Budget Money `gorm:"embedded;embeddedPrefix:budget_" json:"budget"`
 }
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+// lockPrefix отделяет блокировки планировщика от других именованных блокировок
+const lockPrefix = "scheduler:"
+
+// Job - периодическая задача. Run получает время запуска, чтобы все проверки внутри запуска видели одно и то же "сейчас"
+type Job struct {
+	Name string
+	Run  func(ctx context.Context, now time.Time) error
+}
+
+// Scheduler запускает задачи с заданным интервалом. Каждая задача выполняется под блокировкой со своим именем,
+// поэтому при нескольких экземплярах приложения за один запуск ее выполняет только один из них
+type Scheduler struct {
+	locker   storage.Locker
+	interval time.Duration
+	jobs     []Job
+}
+
+func New(locker storage.Locker, interval time.Duration, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		locker:   locker,
+		interval: interval,
+		jobs:     jobs,
+	}
+}
+
+// Run выполняет задачи сразу и затем каждые interval до отмены ctx.
+// Ошибки задач логируются и не останавливают планировщик
+func (s *Scheduler) Run(ctx context.Context) error {
+	slog.Info("Планировщик запущен", "interval", s.interval.String(), "jobs", len(s.jobs))
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runJobs(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runJobs(ctx context.Context) {
+	now := time.Now()
+	for _, job := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger := slog.With("job", job.Name)
+		acquired, err := s.locker.TryWithLock(ctx, lockPrefix+job.Name, func(ctx context.Context) error {
+			return job.Run(ctx, now)
+		})
+		switch {
+		case err != nil && ctx.Err() == nil:
+			logger.Error("Ошибка фоновой задачи", "error", err)
+		case !acquired:
+			logger.Debug("Фоновая задача уже выполняется другим экземпляром")
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"strings"
+	"time"
+	"zadanie-6105/cmd/app/internal/auth"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/service"
+)
+
+const userLocalsKey = "user"
+
+// AuthMiddleware проверяет bearer-токен и кладет сотрудника в c.Locals.
+// В режиме совместимости запросы без токена пропускаются, и пользователь определяется по username.
+func AuthMiddleware(tokens *auth.TokenManager, users *service.UserService, legacy bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		if header == "" {
+			if legacy {
+				return c.Next()
+			}
+			return &APIError{Status: fiber.StatusUnauthorized, Code: CodeUnauthorized}
+		}
+
+		tokenString, found := strings.CutPrefix(header, "Bearer ")
+		if !found {
+			return &APIError{Status: fiber.StatusUnauthorized, Code: CodeInvalidToken, Message: msgInvalidAuthHeader}
+		}
+
+		username, err := tokens.Parse(strings.TrimSpace(tokenString))
+		if err != nil {
+			return &APIError{Status: fiber.StatusUnauthorized, Code: CodeInvalidToken}
+		}
+
+		user, err := users.GetByUsername(c.UserContext(), username)
+		if err != nil {
+			return err
+		}
+
+		c.Locals(userLocalsKey, user)
+		return c.Next()
+	}
+}
+
+// IssueToken выпускает токен для существующего сотрудника по ключу выпуска из заголовка X-Auth-Key.
+// Вне режима совместимости ключ обязателен, это проверяется при загрузке конфигурации
+func IssueToken(tokens *auth.TokenManager, users *service.UserService, issuerKey string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if issuerKey != "" && subtle.ConstantTimeCompare([]byte(c.Get("X-Auth-Key")), []byte(issuerKey)) != 1 {
+			return &APIError{Status: fiber.StatusUnauthorized, Code: CodeUnauthorized, Message: msgInvalidIssuerKey}
+		}
+
+		var request struct {
+			Username string `json:"username" validate:"required"`
+		}
+		if err := c.BodyParser(&request); err != nil {
+			return invalidRequest()
+		}
+
+		if err := validate.Struct(&request); err != nil {
+			return validationFailed(err)
+		}
+
+		user, err := users.GetByUsername(c.UserContext(), request.Username)
+		if err != nil {
+			return err
+		}
+
+		token, expiresAt, err := tokens.Issue(user.Username)
+		if err != nil {
+			return fmt.Errorf("issue token: %w", err)
+		}
+
+		return c.Status(200).JSON(fiber.Map{
+			"token":     token,
+			"tokenType": "Bearer",
+			"expiresAt": expiresAt.Format(time.RFC3339),
+		})
+	}
+}
+
+// currentUser возвращает сотрудника из токена, а в режиме совместимости ищет его по username из запроса
+func (h *Handler) currentUser(c *fiber.Ctx, username string) (models2.Employee, error) {
+	if user, ok := c.Locals(userLocalsKey).(models2.Employee); ok {
+		return user, nil
+	}
+
+	if username == "" {
+		return models2.Employee{}, invalidParameters()
+	}
+
+	return h.users.GetByUsername(c.UserContext(), username)
+}
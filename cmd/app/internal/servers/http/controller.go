@@ -1,991 +1,835 @@
 package http
 
 import (
-	"errors"
-	"fmt"
-	"github.com/go-playground/validator/v10"
+	"encoding/json"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
+	"github.com/shopspring/decimal"
 	"strconv"
 	"strings"
 	"time"
 	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/service"
+	"zadanie-6105/cmd/app/internal/storage"
 )
 
-var validate = validator.New()
+type Handler struct {
+	tenders *service.TenderService
+	bids    *service.BidService
+	users   *service.UserService
+}
 
-func checkOrganizationResponsibility(db *gorm.DB, userID uuid.UUID, organizationID uuid.UUID) error {
-	var orgResp models2.OrganizationResponsible
-	if err := db.Where("user_id = ? AND organization_id = ?", userID, organizationID).First(&orgResp).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fiber.NewError(fiber.StatusForbidden, "Недостаточно прав для выполнения действия.")
-		}
-		return fiber.NewError(fiber.StatusInternalServerError, "Ошибка при проверке ответственности за организацию")
+func NewHandler(tenders *service.TenderService, bids *service.BidService, users *service.UserService) *Handler {
+	return &Handler{
+		tenders: tenders,
+		bids:    bids,
+		users:   users,
 	}
-
-	return nil
 }
 
-func CreateTender(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
-
+func (h *Handler) CreateTender(c *fiber.Ctx) error {
 	type CreateTenderRequest struct {
-		Name            string    `json:"name" validate:"required"`
-		Description     string    `json:"description"`
-		ServiceType     string    `json:"serviceType" validate:"required"`
+		Name            string    `json:"name" validate:"required,max=100"`
+		Description     string    `json:"description" validate:"max=500"`
+		ServiceType     string    `json:"serviceType" validate:"required,service_type"`
+		Status          string    `json:"status" validate:"omitempty,tender_status"`
 		OrganizationID  uuid.UUID `json:"organizationId" validate:"required"`
-		CreatorUsername string    `json:"creatorUsername" validate:"required"`
+		CreatorUsername string    `json:"creatorUsername" validate:"max=100"`
+		// SubmissionDeadline - срок подачи предложений, должен быть в будущем
+		SubmissionDeadline *time.Time `json:"submissionDeadline" validate:"omitempty,gt"`
+		// PublishAt - время автоматической публикации, должно быть в будущем
+		PublishAt *time.Time    `json:"publishAt" validate:"omitempty,gt"`
+		Budget    *moneyRequest `json:"budget" validate:"omitempty"`
 	}
 
 	var request CreateTenderRequest
 	if err := c.BodyParser(&request); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Данные неправильно сформированы или не соответствуют требованиям.",
-		})
+		return invalidRequest()
 	}
 
 	if err := validate.Struct(&request); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Данные неправильно сформированы или не соответствуют требованиям.",
-		})
+		return validationFailed(err)
 	}
 
-	var user models2.Employee
-	if err := db.Where("username = ?", request.CreatorUsername).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(401).JSON(fiber.Map{
-				"reason": "Пользователь не существует или некорректен.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке пользователя",
-		})
+	user, err := h.currentUser(c, request.CreatorUsername)
+	if err != nil {
+		return err
 	}
 
-	var orgResp models2.OrganizationResponsible
-	if err := db.Where("user_id = ? AND organization_id = ?", user.ID, request.OrganizationID).First(&orgResp).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(403).JSON(fiber.Map{
-				"reason": "Недостаточно прав для выполнения действия.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке прав пользователя",
-		})
-	}
+	tender, err := h.tenders.Create(c.UserContext(), user, service.CreateTenderInput{
+		Name:           request.Name,
+		Description:    request.Description,
+		ServiceType:    request.ServiceType,
+		OrganizationID: request.OrganizationID,
 
-	tender := models2.Tender{
-		ID:              uuid.New(),
-		Name:            request.Name,
-		Description:     request.Description,
-		ServiceType:     request.ServiceType,
-		Status:          models2.TenderStatusCreated,
-		OrganizationID:  request.OrganizationID,
-		CreatorUsername: request.CreatorUsername,
-		Version:         1,
+		SubmissionDeadline: request.SubmissionDeadline,
+		PublishAt:          request.PublishAt,
+		Budget:             request.Budget.money(),
+	})
+	if err != nil {
+		return err
 	}
 
-	if err := db.Create(&tender).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Не удалось создать тендер",
-		})
-	}
+	setETag(c, tender.Version)
+	return c.Status(200).JSON(tenderResponse(tender))
+}
 
-	// Запись в таблицу версий
-	tenderVersion := models2.TenderVersion{
-		ID:          uuid.New(),
-		TenderID:    tender.ID,
-		Name:        tender.Name,
-		Description: tender.Description,
-		Status:      tender.Status,
-		Version:     1,
-		CreatedAt:   tender.CreatedAt,
+func (h *Handler) GetUserTenders(c *fiber.Ctx) error {
+	limit, offset, err := parsePagination(c, 5)
+	if err != nil {
+		return err
 	}
 
-	if err := db.Create(&tenderVersion).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Не удалось создать запись о версии тендера",
-		})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	response := models2.TenderResponse{
-		ID:             tender.ID,
-		Name:           tender.Name,
-		Description:    tender.Description,
-		ServiceType:    tender.ServiceType,
-		Status:         tender.Status,
-		OrganizationID: tender.OrganizationID,
-		Version:        1,
-		CreatedAt:      tender.CreatedAt,
+	tenders, err := h.tenders.ListByUser(c.UserContext(), user, limit, offset)
+	if err != nil {
+		return err
 	}
 
-	return c.Status(200).JSON(response)
+	return c.Status(200).JSON(tenders)
 }
 
-func GetUserTenders(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
-	username := c.Query("username")
-	limitStr := c.Query("limit", "5")
-	offsetStr := c.Query("offset", "0")
-
-	if username == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Параметр username обязателен",
-		})
+func (h *Handler) GetTenders(c *fiber.Ctx) error {
+	limit, offset, err := parsePagination(c, 5)
+	if err != nil {
+		return err
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Некорректное значение параметра limit",
-		})
+	var query struct {
+		ServiceType string `query:"serviceType" validate:"omitempty,service_type"`
+		Status      string `query:"status" validate:"omitempty,tender_status"`
+		// Границы бюджета включительно. Суммы в разных валютах не сравнимы, поэтому с границами обязательна валюта
+		BudgetMin      string `query:"budgetMin" validate:"omitempty,numeric"`
+		BudgetMax      string `query:"budgetMax" validate:"omitempty,numeric"`
+		BudgetCurrency string `query:"budgetCurrency" validate:"required_with=BudgetMin BudgetMax,omitempty,iso4217"`
+	}
+	if err := parseQuery(c, &query); err != nil {
+		return err
 	}
 
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Некорректное значение параметра offset",
-		})
+	filter := storage.TenderFilter{
+		ServiceType:    query.ServiceType,
+		BudgetCurrency: query.BudgetCurrency,
+		Limit:          limit,
+		Offset:         offset,
 	}
 
-	var user models2.Employee
-	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(401).JSON(fiber.Map{
-				"reason": "Пользователя не существует",
-			})
+	if query.Status != "" {
+		if filter.Status, err = models2.ParseTenderStatus(query.Status); err != nil {
+			return invalidParameters()
 		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке пользователя",
-		})
 	}
-
-	var tenders []models2.Tender
-	if err := db.Where("creator_username = ?", username).
-		Order("name ASC").
-		Limit(limit).
-		Offset(offset).
-		Find(&tenders).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении тендеров",
-		})
+	if filter.BudgetMin, err = parseDecimal(query.BudgetMin); err != nil {
+		return invalidParameters()
 	}
-
-	if len(tenders) == 0 {
-		return c.Status(200).JSON([]fiber.Map{})
+	if filter.BudgetMax, err = parseDecimal(query.BudgetMax); err != nil {
+		return invalidParameters()
 	}
 
-	var response []fiber.Map
-
-	for _, tender := range tenders {
-		var tenderVersions []models2.TenderVersion
-		if err := db.Where("tender_id = ?", tender.ID).
-			Order("version DESC").
-			Find(&tenderVersions).Error; err != nil {
-			return c.Status(500).JSON(fiber.Map{
-				"reason": "Ошибка при получении версий тендера",
-			})
-		}
-
-		for _, version := range tenderVersions {
-			versionData := fiber.Map{
-				"id":             tender.ID,
-				"name":           version.Name,
-				"description":    version.Description,
-				"serviceType":    version.ServiceType,
-				"status":         version.Status,
-				"organizationId": tender.OrganizationID,
-				"createdAt":      version.CreatedAt,
-				"version":        version.Version,
-			}
-			response = append(response, versionData)
-		}
+	tenders, err := h.tenders.List(c.UserContext(), filter)
+	if err != nil {
+		return err
 	}
 
-	return c.Status(200).JSON(response)
+	return c.Status(200).JSON(tenders)
 }
 
-func GetTenders(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
-
-	limitStr := c.Query("limit", "5")
-	offsetStr := c.Query("offset", "0")
-	serviceType := c.Query("serviceType")
-	statusFilter := c.Query("status")
+func (h *Handler) GetTenderStatus(c *fiber.Ctx) error {
+	tenderID, err := uuid.Parse(c.Params("tenderId"))
+	if err != nil {
+		return invalidRequest()
+	}
 
-	limit, err := strconv.Atoi(limitStr)
+	user, err := h.currentUser(c, c.Query("username"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Некорректное значение параметра limit",
-		})
+		return err
 	}
 
-	offset, err := strconv.Atoi(offsetStr)
+	status, err := h.tenders.GetStatus(c.UserContext(), user, tenderID)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Некорректное значение параметра offset",
-		})
+		return err
 	}
 
-	// Запрос тендеров с фильтрами
-	var tenders []models2.Tender
-	query := db.Model(&models2.Tender{}).Limit(limit).Offset(offset)
+	return c.Status(200).JSON(status)
+}
 
-	if serviceType != "" {
-		query = query.Where("service_type = ?", serviceType)
+func (h *Handler) UpdateTenderStatus(c *fiber.Ctx) error {
+	tenderID, err := uuid.Parse(c.Params("tenderId"))
+	if err != nil {
+		return invalidParameters()
 	}
 
-	if statusFilter == "PUBLISHED" {
-		query = query.Where("status = ?", "PUBLISHED")
+	var query struct {
+		Status string `query:"status" validate:"required,tender_status"`
+	}
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+	status, err := models2.ParseTenderStatus(query.Status)
+	if err != nil {
+		return invalidParameters()
 	}
 
-	if err := query.Find(&tenders).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении тендеров",
-		})
+	expectedVersion, err := parseExpectedVersion(c)
+	if err != nil {
+		return err
 	}
 
-	if len(tenders) == 0 {
-		return c.Status(200).JSON([]fiber.Map{})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	var response []fiber.Map
+	tender, err := h.tenders.UpdateStatus(c.UserContext(), user, tenderID, status, expectedVersion)
+	if err != nil {
+		return err
+	}
 
-	for _, tender := range tenders {
-		var versions []models2.TenderVersion
-		if err := db.Where("tender_id = ?", tender.ID).
-			Order("name ASC").
-			Find(&versions).Error; err != nil {
-			return c.Status(500).JSON(fiber.Map{
-				"reason": "Ошибка при получении версий тендера",
-			})
-		}
+	setETag(c, tender.Version)
+	return c.Status(200).JSON(tenderResponse(tender))
+}
 
-		for _, version := range versions {
-			tenderData := fiber.Map{
-				"id":             tender.ID,
-				"name":           version.Name,
-				"description":    version.Description,
-				"serviceType":    version.ServiceType,
-				"status":         version.Status,
-				"organizationId": tender.OrganizationID,
-				"createdAt":      version.CreatedAt,
-				"version":        version.Version,
-			}
-			response = append(response, tenderData)
-		}
+func (h *Handler) UpdateTender(c *fiber.Ctx) error {
+	tenderID, err := uuid.Parse(c.Params("tenderId"))
+	if err != nil {
+		return invalidRequest()
 	}
 
-	return c.Status(200).JSON(response)
-}
+	var request struct {
+		Name        string `json:"name" validate:"max=100"`
+		Description string `json:"description" validate:"max=500"`
+		ServiceType string `json:"serviceType" validate:"omitempty,service_type"`
 
-func GetTenderStatus(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
+		// SubmissionDeadline со значением null снимает срок подачи предложений
+		SubmissionDeadline *time.Time `json:"submissionDeadline" validate:"omitempty,gt"`
+		PublishAt          *time.Time `json:"publishAt" validate:"omitempty,gt"`
 
-	tenderID := c.Params("tenderId")
-	username := c.Query("username")
+		Budget *moneyRequest `json:"budget" validate:"omitempty"`
+	}
+	if err := c.BodyParser(&request); err != nil {
+		return invalidRequest()
+	}
 
-	if username == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Данные неправильно сформированы или не соответствуют требованиям.",
-		})
+	if err := validate.Struct(&request); err != nil {
+		return validationFailed(err)
 	}
 
-	parsedTenderID, err := uuid.Parse(tenderID)
+	clearDeadline, err := isNullField(c, "submissionDeadline")
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Данные неправильно сформированы или не соответствуют требованиям.",
-		})
+		return invalidRequest()
 	}
 
-	var user models2.Employee
-	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(401).JSON(fiber.Map{
-				"reason": "Пользователь не существует или некорректен.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке пользователя",
-		})
+	expectedVersion, err := parseExpectedVersion(c)
+	if err != nil {
+		return err
 	}
 
-	var tender models2.Tender
-	if err := db.First(&tender, "id = ?", parsedTenderID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(404).JSON(fiber.Map{
-				"reason": "Тендер не найден",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении тендера",
-		})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	if tender.Status == "CREATED" || tender.Status == "CLOSED" {
-		var orgResp models2.OrganizationResponsible
-		if err := db.Where("user_id = ? AND organization_id = ?", user.ID, tender.OrganizationID).First(&orgResp).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return c.Status(403).JSON(fiber.Map{
-					"reason": "Недостаточно прав для выполнения действия.",
-				})
-			}
-			return c.Status(500).JSON(fiber.Map{
-				"reason": "Ошибка при проверке ответственности за организацию",
-			})
-		}
-	} else if tender.Status != "PUBLISHED" {
-		return c.Status(403).JSON(fiber.Map{
-			"reason": "Недостаточно прав для выполнения действия.",
-		})
+	tender, err := h.tenders.Edit(c.UserContext(), user, tenderID, service.TenderPatch{
+		Name:        request.Name,
+		Description: request.Description,
+		ServiceType: request.ServiceType,
+
+		SubmissionDeadline:      request.SubmissionDeadline,
+		ClearSubmissionDeadline: clearDeadline,
+		PublishAt:               request.PublishAt,
+		Budget:                  request.Budget.money(),
+	}, expectedVersion)
+	if err != nil {
+		return err
 	}
 
-	return c.SendString(string(tender.Status))
+	setETag(c, tender.Version)
+	return c.Status(200).JSON(tenderResponse(tender))
 }
 
-func UpdateTenderStatus(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
+func (h *Handler) RollbackTender(c *fiber.Ctx) error {
+	tenderID, err := uuid.Parse(c.Params("tenderId"))
+	if err != nil {
+		return invalidParameters()
+	}
 
-	tenderID := c.Params("tenderId")
-	newStatus := c.Query("status")
-	username := c.Query("username")
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil || version < 1 {
+		return invalidParameters()
+	}
 
-	if tenderID == "" || newStatus == "" || username == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+	expectedVersion, err := parseExpectedVersion(c)
+	if err != nil {
+		return err
 	}
 
-	parsedTenderID, err := uuid.Parse(tenderID)
+	user, err := h.currentUser(c, c.Query("username"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+		return err
 	}
 
-	newStatus = strings.ToUpper(newStatus)
+	tender, err := h.tenders.Rollback(c.UserContext(), user, tenderID, version, expectedVersion)
+	if err != nil {
+		return err
+	}
 
-	var status models2.TenderStatusType
-	switch models2.TenderStatusType(newStatus) {
-	case models2.TenderStatusCreated, models2.TenderStatusPublished, models2.TenderStatusClosed:
-		status = models2.TenderStatusType(newStatus)
-	default:
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+	setETag(c, tender.Version)
+	return c.Status(200).JSON(tenderResponse(tender))
+}
+
+// GetTenderTransitions отдает историю смены статусов тендера: из какого статуса, в какой, кто и когда перевел
+func (h *Handler) GetTenderTransitions(c *fiber.Ctx) error {
+	tenderID, err := uuid.Parse(c.Params("tenderId"))
+	if err != nil {
+		return invalidParameters()
 	}
 
-	var user models2.Employee
-	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(401).JSON(fiber.Map{
-				"reason": "Пользователь не существует или некорректен.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке пользователя",
-		})
+	limit, offset, err := parsePagination(c, 5)
+	if err != nil {
+		return err
 	}
 
-	var tender models2.Tender
-	if err := db.First(&tender, "id = ?", parsedTenderID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(404).JSON(fiber.Map{
-				"reason": "Тендер не найден",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении тендера",
-		})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	if err := checkOrganizationResponsibility(db, user.ID, tender.OrganizationID); err != nil {
+	transitions, err := h.tenders.ListTransitions(c.UserContext(), user, tenderID, limit, offset)
+	if err != nil {
 		return err
 	}
 
-	var latestVersion models2.TenderVersion
-	if err := db.Where("tender_id = ?", tender.ID).
-		Order("version DESC").
-		First(&latestVersion).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении последней версии тендера",
-		})
+	return c.Status(200).JSON(transitions)
+}
+
+// GetScheduledTenders отдает тендеры организации, публикация которых запланирована, но еще не выполнена
+func (h *Handler) GetScheduledTenders(c *fiber.Ctx) error {
+	limit, offset, err := parsePagination(c, 5)
+	if err != nil {
+		return err
 	}
 
-	latestVersion.Status = status
-	if err := db.Save(&latestVersion).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при обновлении статуса последней версии тендера",
-		})
+	var query struct {
+		OrganizationID string `query:"organizationId" validate:"required,uuid"`
+	}
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+	organizationID, err := uuid.Parse(query.OrganizationID)
+	if err != nil {
+		return badRequest(msgInvalidOrganizationID)
 	}
 
-	tender.Status = status
-	if err := db.Save(&tender).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при обновлении статуса тендера",
-		})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	response := models2.TenderResponse{
-		ID:             tender.ID,
-		Name:           tender.Name,
-		Description:    tender.Description,
-		ServiceType:    tender.ServiceType,
-		Status:         tender.Status,
-		CreatedAt:      tender.CreatedAt,
-		OrganizationID: tender.OrganizationID,
+	tenders, err := h.tenders.ListScheduled(c.UserContext(), user, organizationID, limit, offset)
+	if err != nil {
+		return err
 	}
 
+	response := make([]models2.TenderResponse, 0, len(tenders))
+	for _, tender := range tenders {
+		response = append(response, tenderResponse(tender))
+	}
 	return c.Status(200).JSON(response)
 }
 
-func UpdateTender(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
+// CancelTenderPublication отменяет запланированную публикацию, тендер остается в статусе Created
+func (h *Handler) CancelTenderPublication(c *fiber.Ctx) error {
+	tenderID, err := uuid.Parse(c.Params("tenderId"))
+	if err != nil {
+		return invalidParameters()
+	}
 
-	tenderID := c.Params("tenderId")
-	username := c.Query("username")
+	expectedVersion, err := parseExpectedVersion(c)
+	if err != nil {
+		return err
+	}
 
-	if tenderID == "" || username == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Данные неправильно сформированы или не соответствуют требованиям.",
-		})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	var user models2.Employee
-	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(401).JSON(fiber.Map{
-				"reason": "Пользователь не существует или некорректен.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке пользователя",
-		})
+	tender, err := h.tenders.CancelScheduledPublication(c.UserContext(), user, tenderID, expectedVersion)
+	if err != nil {
+		return err
 	}
 
-	var tender models2.Tender
-	if err := db.First(&tender, "id = ?", tenderID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(404).JSON(fiber.Map{
-				"reason": "Тендер не найден",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении тендера",
-		})
+	setETag(c, tender.Version)
+	return c.Status(200).JSON(tenderResponse(tender))
+}
+
+func (h *Handler) CreateBid(c *fiber.Ctx) error {
+	type CreateBidInput struct {
+		Name            string `json:"name" validate:"required,max=100"`
+		Description     string `json:"description" validate:"max=500"`
+		Status          string `json:"status" validate:"omitempty,bid_status"`
+		TenderID        string `json:"tenderId" validate:"required,uuid"`
+		OrganizationID  string `json:"organizationId" validate:"required,uuid"`
+		CreatorUsername string `json:"creatorUsername" validate:"max=100"`
+
+		Price *moneyRequest `json:"price" validate:"omitempty"`
 	}
 
-	if err := checkOrganizationResponsibility(db, user.ID, tender.OrganizationID); err != nil {
-		return err
+	var input CreateBidInput
+	if err := c.BodyParser(&input); err != nil {
+		return invalidParameters()
 	}
 
-	// Обработка данных запроса
-	var request struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
-		ServiceType string `json:"serviceType"`
+	if err := validate.Struct(&input); err != nil {
+		return validationFailed(err)
 	}
-	if err := c.BodyParser(&request); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Некорректные данные запроса",
-		})
+
+	tenderID, err := uuid.Parse(input.TenderID)
+	if err != nil {
+		return badRequest(msgInvalidTenderID)
 	}
 
-	isUpdated := false
-	if request.Name != "" && request.Name != tender.Name {
-		tender.Name = request.Name
-		isUpdated = true
+	organizationID, err := uuid.Parse(input.OrganizationID)
+	if err != nil {
+		return badRequest(msgInvalidOrganizationID)
 	}
-	if request.Description != "" && request.Description != tender.Description {
-		tender.Description = request.Description
-		isUpdated = true
+
+	user, err := h.currentUser(c, input.CreatorUsername)
+	if err != nil {
+		return err
 	}
-	if request.ServiceType != "" && request.ServiceType != tender.ServiceType {
-		tender.ServiceType = request.ServiceType
-		isUpdated = true
+
+	bid, err := h.bids.Create(c.UserContext(), user, service.CreateBidInput{
+		Name:           input.Name,
+		Description:    input.Description,
+		TenderID:       tenderID,
+		OrganizationID: organizationID,
+		Price:          input.Price.money(),
+	})
+	if err != nil {
+		return err
 	}
 
-	var version int
-	if isUpdated {
-		var lastVersion models2.TenderVersion
-		if err := db.Where("tender_id = ?", tender.ID).Order("version desc").First(&lastVersion).Error; err == nil {
-			version = lastVersion.Version + 1
-		} else {
-			version = 1
-		}
+	setETag(c, bid.Version)
+	return c.Status(200).JSON(bidResponse(bid))
+}
 
-		tenderVersion := models2.TenderVersion{
-			ID:          uuid.New(),
-			TenderID:    tender.ID,
-			Version:     version,
-			Name:        tender.Name,
-			Description: tender.Description,
-			ServiceType: tender.ServiceType,
-			Status:      tender.Status,
-			CreatedAt:   time.Now(),
-		}
+func (h *Handler) GetUserBids(c *fiber.Ctx) error {
+	limit, offset, err := parsePagination(c, 10)
+	if err != nil {
+		return err
+	}
 
-		if err := db.Create(&tenderVersion).Error; err != nil {
-			return c.Status(500).JSON(fiber.Map{
-				"reason": "Ошибка при сохранении версии тендера",
-			})
-		}
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	if err := db.Save(&tender).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при сохранении изменений",
-		})
+	bids, err := h.bids.ListByUser(c.UserContext(), user, limit, offset)
+	if err != nil {
+		return err
 	}
 
-	return c.Status(200).JSON(tender)
+	return c.Status(200).JSON(bidsResponse(bids))
 }
 
-func RollbackTender(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
-
-	tenderID := c.Params("tenderId")
-	versionStr := c.Params("version")
-	username := c.Query("username")
-
-	if tenderID == "" || versionStr == "" || username == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+func (h *Handler) UpdateBidStatus(c *fiber.Ctx) error {
+	bidID, err := uuid.Parse(c.Params("bidId"))
+	if err != nil {
+		return invalidParameters()
 	}
 
-	var tenderUUID uuid.UUID
-	if err := tenderUUID.UnmarshalText([]byte(tenderID)); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+	var query struct {
+		Status string `query:"status" validate:"required,bid_status"`
+	}
+	if err := parseQuery(c, &query); err != nil {
+		return err
 	}
 
-	var version int
-	if _, err := fmt.Sscan(versionStr, &version); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+	// Согласовать или отклонить предложение можно только решением ответственных
+	status, err := models2.ParseBidStatus(query.Status)
+	if err != nil {
+		return invalidParameters()
+	}
+	if status == models2.BidStatusApproved || status == models2.BidStatusRejected {
+		return badRequest(msgDecisionRequired)
 	}
 
-	var user models2.Employee
-	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(401).JSON(fiber.Map{
-				"reason": "Пользователь не существует или некорректен.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке пользователя",
-		})
+	expectedVersion, err := parseExpectedVersion(c)
+	if err != nil {
+		return err
 	}
 
-	var tender models2.Tender
-	if err := db.First(&tender, "id = ?", tenderUUID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(404).JSON(fiber.Map{
-				"reason": "Тендер не найден",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении тендера",
-		})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	if err := checkOrganizationResponsibility(db, user.ID, tender.OrganizationID); err != nil {
+	bid, err := h.bids.UpdateStatus(c.UserContext(), user, bidID, status, expectedVersion)
+	if err != nil {
 		return err
 	}
 
-	var tenderVersion models2.TenderVersion
-	if err := db.Where("tender_id = ? AND version = ?", tenderUUID, version).First(&tenderVersion).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(404).JSON(fiber.Map{
-				"reason": "Версия тендера не найдена",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении версии тендера",
-		})
+	setETag(c, bid.Version)
+	return c.Status(200).JSON(bidResponse(bid))
+}
+
+func (h *Handler) GetBidStatus(c *fiber.Ctx) error {
+	bidID, err := uuid.Parse(c.Params("bidId"))
+	if err != nil {
+		return invalidRequest()
 	}
 
-	tender.Name = tenderVersion.Name
-	tender.Description = tenderVersion.Description
-	tender.ServiceType = tenderVersion.ServiceType
-	tender.Status = tenderVersion.Status
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
+	}
 
-	if err := db.Save(&tender).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при сохранении изменений тендера",
-		})
+	status, err := h.bids.GetStatus(c.UserContext(), user, bidID)
+	if err != nil {
+		return err
 	}
 
-	var maxVersion int
-	if err := db.Model(&models2.TenderVersion{}).Where("tender_id = ?", tender.ID).Select("COALESCE(MAX(version), 0)").Scan(&maxVersion).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при определении максимальной версии тендера",
-		})
+	return c.Status(200).JSON(status)
+}
+
+func (h *Handler) GetBidsForTender(c *fiber.Ctx) error {
+	tenderID, err := uuid.Parse(c.Params("tenderId"))
+	if err != nil {
+		return invalidParameters()
 	}
 
-	newVersion := models2.TenderVersion{
-		TenderID:    tender.ID,
-		Version:     maxVersion + 1,
-		Name:        tender.Name,
-		Description: tender.Description,
-		ServiceType: tender.ServiceType,
-		Status:      tender.Status,
-		CreatedAt:   time.Now(),
+	limit, offset, err := parsePagination(c, 5)
+	if err != nil {
+		return err
 	}
 
-	if err := db.Create(&newVersion).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при создании новой версии тендера",
-		})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	response := models2.TenderResponse{
-		ID:             tender.ID,
-		Name:           tender.Name,
-		Description:    tender.Description,
-		ServiceType:    tender.ServiceType,
-		Status:         tender.Status,
-		OrganizationID: tender.OrganizationID,
-		CreatedAt:      tender.CreatedAt,
-		Version:        newVersion.Version,
+	bids, err := h.bids.ListForTender(c.UserContext(), user, tenderID, limit, offset)
+	if err != nil {
+		return err
 	}
 
-	return c.Status(200).JSON(response)
+	return c.Status(200).JSON(bidsResponse(bids))
 }
 
-func CreateBid(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
+func (h *Handler) EditBid(c *fiber.Ctx) error {
+	bidID, err := uuid.Parse(c.Params("bidId"))
+	if err != nil {
+		return invalidRequest()
+	}
 
-	type CreateBidInput struct {
-		Name            string `json:"name" validate:"required"`
-		Description     string `json:"description"`
-		TenderID        string `json:"tenderId" validate:"required"`
-		OrganizationID  string `json:"organizationId" validate:"required"`
-		CreatorUsername string `json:"creatorUsername" validate:"required"`
+	var request struct {
+		Name        *string       `json:"name" validate:"omitempty,min=1,max=100"`
+		Description *string       `json:"description" validate:"omitempty,max=500"`
+		Price       *moneyRequest `json:"price" validate:"omitempty"`
+	}
+	if err := c.BodyParser(&request); err != nil {
+		return invalidRequest()
 	}
 
-	var input CreateBidInput
-	if err := c.BodyParser(&input); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+	if err := validate.Struct(&request); err != nil {
+		return validationFailed(err)
 	}
 
-	tenderID, err := uuid.Parse(input.TenderID)
+	expectedVersion, err := parseExpectedVersion(c)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат идентификатора тендера.",
-		})
+		return err
 	}
 
-	organizationID, err := uuid.Parse(input.OrganizationID)
+	user, err := h.currentUser(c, c.Query("username"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат идентификатора организации.",
-		})
+		return err
 	}
 
-	var tender models2.Tender
-	if err := db.First(&tender, "id = ?", tenderID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(404).JSON(fiber.Map{
-				"reason": "Тендер не найден.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при поиске тендера.",
-		})
+	patch := service.BidPatch{
+		Name:        request.Name,
+		Description: request.Description,
+	}
+	if request.Price != nil {
+		price := request.Price.money()
+		patch.Price = &price
 	}
 
-	if tender.OrganizationID != organizationID {
-		return c.Status(403).JSON(fiber.Map{
-			"reason": "Организация не имеет права делать предложение на этот тендер.",
-		})
+	bid, err := h.bids.Edit(c.UserContext(), user, bidID, patch, expectedVersion)
+	if err != nil {
+		return err
 	}
 
-	bid := models2.Bid{
-		Name:            input.Name,
-		Description:     input.Description,
-		Status:          models2.BidStatusCreated,
-		TenderID:        tenderID,
-		OrganizationID:  organizationID,
-		Version:         1, // Начальная версия
-		CreatorUsername: input.CreatorUsername,
+	setETag(c, bid.Version)
+	return c.Status(200).JSON(bidResponse(bid))
+}
+
+func (h *Handler) RollbackBid(c *fiber.Ctx) error {
+	bidID, err := uuid.Parse(c.Params("bidId"))
+	if err != nil {
+		return invalidParameters()
 	}
 
-	if err := db.Create(&bid).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при создании предложения.",
-		})
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil || version < 1 {
+		return invalidParameters()
 	}
 
-	bidVersion := models2.BidVersion{
-		ID:          uuid.New(),
-		BidID:       bid.ID,
-		Version:     bid.Version,
-		Name:        bid.Name,
-		Description: bid.Description,
-		Status:      bid.Status,
-		CreatedAt:   time.Now(),
+	expectedVersion, err := parseExpectedVersion(c)
+	if err != nil {
+		return err
 	}
 
-	if err := db.Create(&bidVersion).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при создании версии предложения.",
-		})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	response := fiber.Map{
-		"id":              bid.ID.String(),
-		"name":            bid.Name,
-		"description":     bid.Description,
-		"status":          string(bid.Status),
-		"tenderId":        bid.TenderID.String(),
-		"organizationId":  bid.OrganizationID.String(),
-		"creatorUsername": bid.CreatorUsername,
-		"createdAt":       bid.CreatedAt.Format(time.RFC3339),
-		"version":         bid.Version,
+	bid, err := h.bids.Rollback(c.UserContext(), user, bidID, version, expectedVersion)
+	if err != nil {
+		return err
 	}
 
-	return c.Status(200).JSON(response)
+	setETag(c, bid.Version)
+	return c.Status(200).JSON(bidResponse(bid))
 }
 
-func GetUserBids(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
-
-	username := c.Query("username")
-	if username == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Не указан username",
-		})
+func (h *Handler) SubmitBidDecision(c *fiber.Ctx) error {
+	bidID, err := uuid.Parse(c.Params("bidId"))
+	if err != nil {
+		return invalidParameters()
 	}
 
-	limit, offset := 10, 0
-	if l := c.Query("limit"); l != "" {
-		var err error
-		if limit, err = strconv.Atoi(l); err != nil {
-			return c.Status(400).JSON(fiber.Map{"reason": "Некорректное значение limit"})
-		}
+	var query struct {
+		Decision string `query:"decision" validate:"required,bid_decision"`
 	}
-	if o := c.Query("offset"); o != "" {
-		var err error
-		if offset, err = strconv.Atoi(o); err != nil {
-			return c.Status(400).JSON(fiber.Map{"reason": "Некорректное значение offset"})
-		}
+	if err := parseQuery(c, &query); err != nil {
+		return err
 	}
-
-	var bids []models2.Bid
-	if err := db.Where("creator_username = ?", username).
-		Limit(limit).Offset(offset).
-		Find(&bids).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении предложений",
-		})
+	decision, err := models2.ParseBidDecision(query.Decision)
+	if err != nil {
+		return invalidParameters()
 	}
 
-	// Get the latest version for each bid
-	var bidVersions []models2.BidVersion
-	for _, bid := range bids {
-		var latestVersion models2.BidVersion
-		if err := db.Where("bid_id = ?", bid.ID).
-			Order("version DESC").
-			First(&latestVersion).Error; err != nil {
-			if !errors.Is(err, gorm.ErrRecordNotFound) {
-				return c.Status(500).JSON(fiber.Map{
-					"reason": "Ошибка при получении версии предложения",
-				})
-			}
-		} else {
-			bidVersions = append(bidVersions, latestVersion)
-		}
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	bidResponses := make([]fiber.Map, len(bids))
-	for i, bid := range bids {
-		bidResponses[i] = fiber.Map{
-			"id":              bid.ID.String(),
-			"name":            bid.Name,
-			"description":     bid.Description,
-			"status":          bid.Status,
-			"tenderId":        bid.TenderID.String(),
-			"organizationId":  bid.OrganizationID.String(),
-			"creatorUsername": bid.CreatorUsername,
-			"createdAt":       bid.CreatedAt.Format(time.RFC3339),
-			"version":         bid.Version,
-		}
+	bid, err := h.bids.SubmitDecision(c.UserContext(), user, bidID, decision)
+	if err != nil {
+		return err
 	}
 
-	return c.Status(200).JSON(bidResponses)
+	setETag(c, bid.Version)
+	return c.Status(200).JSON(bidResponse(bid))
 }
 
-func UpdateBidStatus(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
+func (h *Handler) SubmitBidFeedback(c *fiber.Ctx) error {
+	bidID, err := uuid.Parse(c.Params("bidId"))
+	if err != nil {
+		return invalidParameters()
+	}
 
-	bidID := c.Params("bidId")
-	newStatus := c.Query("status")
-	username := c.Query("username")
+	var query struct {
+		Feedback string `query:"bidFeedback" validate:"required,max=1000"`
+	}
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
 
-	if bidID == "" || newStatus == "" || username == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+	user, err := h.currentUser(c, c.Query("username"))
+	if err != nil {
+		return err
 	}
 
-	parsedBidID, err := uuid.Parse(bidID)
+	bid, err := h.bids.SubmitFeedback(c.UserContext(), user, bidID, query.Feedback)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+		return err
 	}
 
-	newStatus = strings.ToUpper(newStatus)
+	setETag(c, bid.Version)
+	return c.Status(200).JSON(bidResponse(bid))
+}
 
-	var status models2.BidStatusType
-	switch models2.BidStatusType(newStatus) {
-	case models2.BidStatusCreated, models2.BidStatusPublished, models2.BidStatusCanceled:
-		status = models2.BidStatusType(newStatus)
-	default:
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Неверный формат запроса или его параметры.",
-		})
+func (h *Handler) GetBidReviews(c *fiber.Ctx) error {
+	tenderID, err := uuid.Parse(c.Params("tenderId"))
+	if err != nil {
+		return invalidParameters()
 	}
 
-	var user models2.Employee
-	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(401).JSON(fiber.Map{
-				"reason": "Пользователь не существует или некорректен.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке пользователя",
-		})
+	var query struct {
+		AuthorUsername string `query:"authorUsername" validate:"required,max=100"`
 	}
-
-	var bid models2.Bid
-	if err := db.First(&bid, "id = ?", parsedBidID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(404).JSON(fiber.Map{
-				"reason": "Предложение не найдено",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении предложения",
-		})
+	if err := parseQuery(c, &query); err != nil {
+		return err
 	}
 
-	if err := checkOrganizationResponsibility(db, user.ID, bid.OrganizationID); err != nil {
+	limit, offset, err := parsePagination(c, 5)
+	if err != nil {
 		return err
 	}
 
-	var latestVersion models2.BidVersion
-	if err := db.Where("bid_id = ?", bid.ID).
-		Order("version DESC").
-		First(&latestVersion).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении последней версии предложения",
-		})
+	requester, err := h.currentUser(c, c.Query("requesterUsername"))
+	if err != nil {
+		return err
 	}
 
-	latestVersion.Status = status
-	if err := db.Save(&latestVersion).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при обновлении статуса последней версии предложения",
-		})
+	reviews, err := h.bids.ListReviews(c.UserContext(), requester, tenderID, query.AuthorUsername, limit, offset)
+	if err != nil {
+		return err
 	}
 
-	bid.Status = status
-	if err := db.Save(&bid).Error; err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при обновлении статуса предложения",
+	response := make([]fiber.Map, 0, len(reviews))
+	for _, review := range reviews {
+		response = append(response, fiber.Map{
+			"id":          review.ID.String(),
+			"description": review.Comment,
+			"createdAt":   review.CreatedAt.Format(time.RFC3339),
 		})
 	}
 
-	bidResponse := fiber.Map{
-		"id":              bid.ID.String(),
-		"name":            bid.Name,
-		"description":     bid.Description,
-		"status":          bid.Status,
-		"tenderId":        bid.TenderID.String(),
-		"organizationId":  bid.OrganizationID.String(),
-		"creatorUsername": bid.CreatorUsername,
-		"createdAt":       bid.CreatedAt.Format(time.RFC3339),
-		"version":         bid.Version,
+	return c.Status(200).JSON(response)
+}
+
+// parsePagination разбирает параметры limit и offset, ограничения совпадают со спецификацией
+func parsePagination(c *fiber.Ctx, defaultLimit int) (int, int, error) {
+	query := struct {
+		Limit  int `query:"limit" validate:"min=0,max=50"`
+		Offset int `query:"offset" validate:"min=0"`
+	}{
+		Limit: defaultLimit,
+	}
+	if err := parseQuery(c, &query); err != nil {
+		return 0, 0, err
 	}
 
-	return c.Status(200).JSON(bidResponse)
+	return query.Limit, query.Offset, nil
 }
 
-func GetBidStatus(c *fiber.Ctx) error {
-	db := c.Locals("db").(*gorm.DB)
+// moneyRequest - сумма в теле запроса. amount принимается строкой или числом, currency - код ISO 4217
+type moneyRequest struct {
+	Amount      decimal.Decimal `json:"amount" validate:"money_amount"`
+	Currency    string          `json:"currency" validate:"required,iso4217"`
+	VATIncluded bool            `json:"vatIncluded"`
+}
 
-	bidID := c.Params("bidId")
-	username := c.Query("username")
+// money возвращает сумму из запроса, для отсутствующей суммы - нулевое значение
+func (r *moneyRequest) money() models2.Money {
+	if r == nil {
+		return models2.Money{}
+	}
+	return models2.Money{
+		Amount:      r.Amount,
+		Currency:    r.Currency,
+		VATIncluded: r.VATIncluded,
+	}
+}
 
-	if username == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Данные неправильно сформированы или не соответствуют требованиям.",
-		})
+// isNullField сообщает, передано ли в JSON-теле поле field явным значением null.
+// BodyParser не отличает null от отсутствующего поля, а для правок это разные намерения
+func isNullField(c *fiber.Ctx, field string) (bool, error) {
+	var fields map[string]json.RawMessage
+	if err := c.App().Config().JSONDecoder(c.Body(), &fields); err != nil {
+		return false, err
 	}
+	value, ok := fields[field]
+	return ok && string(value) == "null", nil
+}
 
-	parsedBidID, err := uuid.Parse(bidID)
+// parseDecimal разбирает необязательное десятичное число, для пустой строки возвращает nil
+func parseDecimal(value string) (*decimal.Decimal, error) {
+	if value == "" {
+		return nil, nil
+	}
+	number, err := decimal.NewFromString(value)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"reason": "Данные неправильно сформированы или не соответствуют требованиям.",
-		})
+		return nil, err
 	}
+	return &number, nil
+}
 
-	var user models2.Employee
-	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(401).JSON(fiber.Map{
-				"reason": "Пользователь не существует или некорректен.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке пользователя",
-		})
+// parseQuery разбирает параметры строки запроса в структуру и проверяет их по тегам validate
+func parseQuery(c *fiber.Ctx, query any) error {
+	if err := c.QueryParser(query); err != nil {
+		return invalidParameters()
+	}
+	if err := validate.Struct(query); err != nil {
+		return validationFailed(err)
 	}
+	return nil
+}
 
-	var bid models2.Bid
-	if err := db.First(&bid, "id = ?", parsedBidID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(404).JSON(fiber.Map{
-				"reason": "Предложение не найдено",
-			})
+// parseExpectedVersion возвращает версию, которую видел клиент, из заголовка If-Match или параметра expectedVersion.
+// Если клиент не передал ни то ни другое или передал If-Match: *, возвращается 0 и версия не проверяется
+func parseExpectedVersion(c *fiber.Ctx) (int, error) {
+	value := c.Query("expectedVersion")
+	if ifMatch := strings.TrimSpace(c.Get(fiber.HeaderIfMatch)); ifMatch != "" {
+		if ifMatch == "*" {
+			return 0, nil
 		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении предложения",
-		})
+		value = strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+	}
+	if value == "" {
+		return 0, nil
 	}
 
-	var organization models2.Organization
-	if err := db.First(&organization, "id = ?", bid.OrganizationID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(404).JSON(fiber.Map{
-				"reason": "Организация не найдена",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при получении организации",
-		})
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, badRequest(msgInvalidExpectedVersion)
 	}
 
-	var orgResp models2.OrganizationResponsible
-	if err := db.Where("user_id = ? AND organization_id = ?", user.ID, bid.OrganizationID).First(&orgResp).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.Status(403).JSON(fiber.Map{
-				"reason": "Недостаточно прав для выполнения действия.",
-			})
-		}
-		return c.Status(500).JSON(fiber.Map{
-			"reason": "Ошибка при проверке ответственности за организацию",
-		})
+	return version, nil
+}
+
+// setETag отдает версию тендера или предложения в заголовке ETag для последующего If-Match
+func setETag(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, `"`+strconv.Itoa(version)+`"`)
+}
+
+func tenderResponse(tender models2.Tender) models2.TenderResponse {
+	return models2.TenderResponse{
+		ID:             tender.ID,
+		Name:           tender.Name,
+		Description:    tender.Description,
+		ServiceType:    tender.ServiceType,
+		Status:         tender.Status,
+		OrganizationID: tender.OrganizationID,
+		CreatedAt:      tender.CreatedAt,
+		Version:        tender.Version,
+
+		SubmissionDeadline: tender.SubmissionDeadline,
+		PublishAt:          tender.PublishAt,
+		Budget:             tender.Budget.Ptr(),
+	}
+}
+
+func bidResponse(bid models2.Bid) fiber.Map {
+	response := fiber.Map{
+		"id":              bid.ID.String(),
+		"name":            bid.Name,
+		"description":     bid.Description,
+		"status":          bid.Status,
+		"tenderId":        bid.TenderID.String(),
+		"organizationId":  bid.OrganizationID.String(),
+		"creatorUsername": bid.CreatorUsername,
+		"createdAt":       bid.CreatedAt.Format(time.RFC3339),
+		"version":         bid.Version,
+	}
+	if price := bid.Price.Ptr(); price != nil {
+		response["price"] = price
 	}
+	return response
+}
 
-	return c.SendString(string(bid.Status))
+func bidsResponse(bids []models2.Bid) []fiber.Map {
+	response := make([]fiber.Map, 0, len(bids))
+	for _, bid := range bids {
+		response = append(response, bidResponse(bid))
+	}
+	return response
 }
@@ -0,0 +1,212 @@
+package http
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"strings"
+	"zadanie-6105/cmd/app/internal/logging"
+	"zadanie-6105/cmd/app/internal/service"
+)
+
+// Коды ошибок в поле code. В отличие от reason они не меняются, клиенты должны ориентироваться на них
+const (
+	CodeBadRequest            = "BAD_REQUEST"
+	CodeValidationFailed      = "VALIDATION_FAILED"
+	CodeUnauthorized          = "UNAUTHORIZED"
+	CodeInvalidToken          = "INVALID_TOKEN"
+	CodeUserNotFound          = "USER_NOT_FOUND"
+	CodeForbidden             = "FORBIDDEN"
+	CodeBidTenderMismatch     = "BID_TENDER_MISMATCH"
+	CodeOrganizationNotFound  = "ORGANIZATION_NOT_FOUND"
+	CodeTenderNotFound        = "TENDER_NOT_FOUND"
+	CodeTenderVersionNotFound = "TENDER_VERSION_NOT_FOUND"
+	CodeBidNotFound           = "BID_NOT_FOUND"
+	CodeBidVersionNotFound    = "BID_VERSION_NOT_FOUND"
+	CodeAuthorBidsNotFound    = "AUTHOR_BIDS_NOT_FOUND"
+	CodeDecisionNotAllowed    = "DECISION_NOT_ALLOWED"
+	CodeTenderClosed          = "TENDER_CLOSED"
+	CodeDeadlinePassed        = "SUBMISSION_DEADLINE_PASSED"
+	CodePublicationNotFound   = "SCHEDULED_PUBLICATION_NOT_FOUND"
+	CodePublicationNotAllowed = "PUBLICATION_NOT_ALLOWED"
+	CodeVersionConflict       = "VERSION_CONFLICT"
+	CodeInvalidTransition     = "INVALID_TRANSITION"
+	CodeInternal              = "INTERNAL_ERROR"
+
+	// Коды ошибок самого fiber, остальные строятся из текста статуса
+	codeNotFound         = "NOT_FOUND"
+	codeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+)
+
+// APIError - ошибка, которая отдается клиенту как есть. Обработчики возвращают ее вместо того,
+// чтобы формировать ответ самостоятельно, ответ собирает ErrorHandler
+type APIError struct {
+	Status int
+	Code   string
+	// Message - ключ текста в каталоге messages, если пустой, используется Code
+	Message string
+	Details any
+
+	fields validator.ValidationErrors
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Code + ": " + e.Message
+	}
+	return e.Code
+}
+
+// errorBody - тело ответа с ошибкой, совместимое со схемой errorResponse из спецификации
+type errorBody struct {
+	Code    string `json:"code"`
+	Reason  string `json:"reason"`
+	Details any    `json:"details,omitempty"`
+	// CurrentVersion нужен схеме versionConflictResponse
+	CurrentVersion int `json:"currentVersion,omitempty"`
+}
+
+// domainErrors сопоставляет ошибки сервисного слоя со статусом и кодом ответа
+var domainErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{service.ErrUserNotFound, fiber.StatusUnauthorized, CodeUserNotFound},
+	{service.ErrForbidden, fiber.StatusForbidden, CodeForbidden},
+	{service.ErrBidTenderMismatch, fiber.StatusForbidden, CodeBidTenderMismatch},
+	{service.ErrOrganizationNotFound, fiber.StatusNotFound, CodeOrganizationNotFound},
+	{service.ErrTenderNotFound, fiber.StatusNotFound, CodeTenderNotFound},
+	{service.ErrTenderVersionNotFound, fiber.StatusNotFound, CodeTenderVersionNotFound},
+	{service.ErrBidNotFound, fiber.StatusNotFound, CodeBidNotFound},
+	{service.ErrBidVersionNotFound, fiber.StatusNotFound, CodeBidVersionNotFound},
+	{service.ErrAuthorBidsNotFound, fiber.StatusNotFound, CodeAuthorBidsNotFound},
+	{service.ErrDecisionNotAllowed, fiber.StatusBadRequest, CodeDecisionNotAllowed},
+	{service.ErrTenderClosed, fiber.StatusConflict, CodeTenderClosed},
+	{service.ErrSubmissionDeadlinePassed, fiber.StatusConflict, CodeDeadlinePassed},
+	{service.ErrPublicationNotScheduled, fiber.StatusNotFound, CodePublicationNotFound},
+	{service.ErrPublicationNotAllowed, fiber.StatusConflict, CodePublicationNotAllowed},
+	{service.ErrVersionConflict, fiber.StatusConflict, CodeVersionConflict},
+	{service.ErrInvalidTransition, fiber.StatusConflict, CodeInvalidTransition},
+}
+
+// badRequest - ошибка в параметрах запроса, message - ключ уточняющего текста
+func badRequest(message string) *APIError {
+	return &APIError{Status: fiber.StatusBadRequest, Code: CodeBadRequest, Message: message}
+}
+
+// invalidRequest - тело запроса не удалось разобрать
+func invalidRequest() *APIError {
+	return badRequest(msgInvalidBody)
+}
+
+// invalidParameters - параметры пути или строки запроса имеют неверный формат
+func invalidParameters() *APIError {
+	return badRequest(CodeBadRequest)
+}
+
+// validationFailed - ошибка validator, в details попадет сообщение по каждому полю
+func validationFailed(err error) *APIError {
+	apiErr := &APIError{Status: fiber.StatusBadRequest, Code: CodeValidationFailed}
+	if !errors.As(err, &apiErr.fields) {
+		apiErr.Message = msgInvalidBody
+	}
+	return apiErr
+}
+
+// ErrorHandler превращает ошибку обработчика или middleware в ответ с полями code, reason и details
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	lang := language(c)
+	body, status := resolveError(err, lang)
+	c.Set(fiber.HeaderContentLanguage, lang)
+
+	if status >= fiber.StatusInternalServerError {
+		logging.FromContext(c.UserContext()).Error("Ошибка обработки запроса", "error", err)
+	}
+
+	// При конфликте версий клиенту нужна текущая версия, чтобы перечитать объект и повторить запрос
+	var conflictErr *service.VersionConflictError
+	if errors.As(err, &conflictErr) {
+		body.CurrentVersion = conflictErr.CurrentVersion
+		body.Details = fiber.Map{"currentVersion": conflictErr.CurrentVersion}
+		setETag(c, conflictErr.CurrentVersion)
+	}
+
+	// При недопустимом переходе клиент получает статусы, в которые тендер или предложение можно перевести
+	var transitionErr *service.TransitionError
+	if errors.As(err, &transitionErr) {
+		details := fiber.Map{
+			"from":    transitionErr.From,
+			"to":      transitionErr.To,
+			"allowed": transitionErr.Allowed,
+		}
+		if transitionErr.Guard != "" {
+			details["guard"] = transitionErr.Guard
+			if reason, ok := message(lang, transitionErr.Guard); ok {
+				body.Reason = reason
+			}
+		}
+		body.Details = details
+	}
+
+	return c.Status(status).JSON(body)
+}
+
+func resolveError(err error, lang string) (errorBody, int) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		key := apiErr.Message
+		if key == "" {
+			key = apiErr.Code
+		}
+		reason, _ := message(lang, key)
+
+		body := errorBody{Code: apiErr.Code, Reason: reason, Details: apiErr.Details}
+		if len(apiErr.fields) > 0 {
+			body.Details = fiber.Map{"fields": fieldErrors(apiErr.fields, lang)}
+		}
+		return body, apiErr.Status
+	}
+
+	for _, domainErr := range domainErrors {
+		if errors.Is(err, domainErr.err) {
+			reason, _ := message(lang, domainErr.code)
+			return errorBody{Code: domainErr.code, Reason: reason}, domainErr.status
+		}
+	}
+
+	// Ошибки самого fiber: неизвестный маршрут, неподдерживаемый метод, слишком большое тело
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) && fiberErr.Code < fiber.StatusInternalServerError {
+		code := statusCode(fiberErr.Code)
+		reason, ok := message(lang, code)
+		if !ok {
+			reason = fiberErr.Message
+		}
+		return errorBody{Code: code, Reason: reason}, fiberErr.Code
+	}
+
+	reason, _ := message(lang, CodeInternal)
+	return errorBody{Code: CodeInternal, Reason: reason}, fiber.StatusInternalServerError
+}
+
+// statusCode строит код ошибки из текста HTTP-статуса, например 404 -> NOT_FOUND
+func statusCode(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(utils.StatusMessage(status), " ", "_"))
+}
+
+// HandleErrors превращает ошибки маршрутов в ответ сразу, а не после всех middleware,
+// чтобы журнал запросов и метрики видели итоговый статус ответа
+func HandleErrors() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+		if err == nil {
+			return nil
+		}
+		if err := c.App().ErrorHandler(c, err); err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return nil
+	}
+}
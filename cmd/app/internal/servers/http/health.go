@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"sync"
+	"time"
+)
+
+const (
+	healthStatusOK   = "ok"
+	healthStatusFail = "fail"
+)
+
+// HealthCheck - проверка зависимости, от которой зависит готовность приложения принимать запросы
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type healthCheckResult struct {
+	Status     string `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Liveness отвечает, что процесс жив. Зависимости не проверяются, чтобы оркестратор не перезапускал под,
+// когда недоступна только база
+func Liveness(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": healthStatusOK,
+	})
+}
+
+// Readiness параллельно выполняет проверки с общим timeout и возвращает 503, если хотя бы одна не прошла
+func Readiness(checks []HealthCheck, timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		var (
+			mu      sync.Mutex
+			wg      sync.WaitGroup
+			results = make(map[string]healthCheckResult, len(checks))
+		)
+		for _, check := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				startedAt := time.Now()
+				result := healthCheckResult{Status: healthStatusOK}
+				if err := check.Check(ctx); err != nil {
+					result.Status, result.Error = healthStatusFail, err.Error()
+				}
+				result.DurationMs = time.Since(startedAt).Milliseconds()
+
+				mu.Lock()
+				results[check.Name] = result
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		status, code := healthStatusOK, fiber.StatusOK
+		for _, result := range results {
+			if result.Status != healthStatusOK {
+				status, code = healthStatusFail, fiber.StatusServiceUnavailable
+			}
+		}
+
+		return c.Status(code).JSON(fiber.Map{
+			"status": status,
+			"checks": results,
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+	"zadanie-6105/cmd/app/internal/logging"
+)
+
+const (
+	headerRequestID = "X-Request-ID"
+	// maxRequestIDLength ограничивает X-Request-ID от клиента, чтобы в лог не попадали произвольные большие строки
+	maxRequestIDLength = 128
+)
+
+// RequestContext принимает X-Request-ID от клиента или генерирует его, возвращает в ответе
+// и кладет в контекст запроса логгер с request_id
+func RequestContext(logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(headerRequestID)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+		c.Set(headerRequestID, requestID)
+
+		requestLogger := logger.With("request_id", requestID)
+		c.SetUserContext(logging.WithLogger(c.UserContext(), requestLogger))
+
+		return c.Next()
+	}
+}
+
+// AccessLog пишет одну строку на каждый запрос после его обработки
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		startedAt := time.Now()
+		err := c.Next()
+
+		// Ошибки к этому моменту уже превращены в ответ middleware HandleErrors
+		status := c.Response().StatusCode()
+
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		ctx := c.UserContext()
+		logging.FromContext(ctx).Log(ctx, level, "Запрос обработан",
+			"method", c.Method(),
+			"path", c.Path(),
+			"route", c.Route().Path,
+			"status", status,
+			"duration_ms", float64(time.Since(startedAt).Microseconds())/1000,
+			"bytes", len(c.Response().Body()),
+			"ip", c.IP(),
+		)
+
+		return err
+	}
+}
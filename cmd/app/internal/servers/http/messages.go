@@ -0,0 +1,141 @@
+package http
+
+import (
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"zadanie-6105/cmd/app/internal/service"
+)
+
+const (
+	langRussian = "ru"
+	langEnglish = "en"
+	// defaultLanguage используется, если клиент не передал Accept-Language или ни один из языков не поддерживается
+	defaultLanguage = langRussian
+)
+
+// Ключи уточняющих сообщений. Для остальных ошибок ключом служит код ошибки
+const (
+	msgInvalidBody            = "INVALID_BODY"
+	msgInvalidTenderID        = "INVALID_TENDER_ID"
+	msgInvalidOrganizationID  = "INVALID_ORGANIZATION_ID"
+	msgInvalidExpectedVersion = "INVALID_EXPECTED_VERSION"
+	msgDecisionRequired       = "DECISION_REQUIRED"
+	msgInvalidAuthHeader      = "INVALID_AUTH_HEADER"
+	msgInvalidIssuerKey       = "INVALID_ISSUER_KEY"
+)
+
+// messages - каталог текстов для поля reason по языку и ключу
+var messages = map[string]map[string]string{
+	langRussian: {
+		CodeBadRequest:            "Неверный формат запроса или его параметры.",
+		CodeValidationFailed:      "Данные не соответствуют требованиям.",
+		CodeUnauthorized:          "Требуется авторизация.",
+		CodeInvalidToken:          "Токен недействителен или истек.",
+		CodeUserNotFound:          "Пользователь не существует или некорректен.",
+		CodeForbidden:             "Недостаточно прав для выполнения действия.",
+		CodeBidTenderMismatch:     "Организация не имеет права делать предложение на этот тендер.",
+		CodeOrganizationNotFound:  "Организация не найдена.",
+		CodeTenderNotFound:        "Тендер не найден.",
+		CodeTenderVersionNotFound: "Версия тендера не найдена.",
+		CodeBidNotFound:           "Предложение не найдено.",
+		CodeBidVersionNotFound:    "Версия предложения не найдена.",
+		CodeAuthorBidsNotFound:    "Предложения автора на тендер не найдены.",
+		CodeDecisionNotAllowed:    "Решение не может быть отправлено.",
+		CodeTenderClosed:          "Тендер закрыт, новые предложения не принимаются.",
+		CodeDeadlinePassed:        "Срок подачи предложений по тендеру истек.",
+		CodePublicationNotFound:   "У тендера нет запланированной публикации.",
+		CodePublicationNotAllowed: "Запланировать публикацию можно только для еще не опубликованного тендера.",
+		CodeVersionConflict:       "Объект был изменен другим пользователем.",
+		CodeInvalidTransition:     "Переход в запрошенный статус невозможен.",
+		CodeInternal:              "Внутренняя ошибка сервера.",
+		codeNotFound:              "Маршрут не найден.",
+		codeMethodNotAllowed:      "Метод не поддерживается.",
+
+		service.GuardDescriptionRequired:     "Тендер без описания нельзя опубликовать.",
+		service.GuardPublishedTenderRequired: "Предложение можно опубликовать только по опубликованному тендеру.",
+		service.GuardFinalStatus:             "Отмененное, согласованное или отклоненное предложение нельзя изменить.",
+
+		msgInvalidBody:            "Данные неправильно сформированы или не соответствуют требованиям.",
+		msgInvalidTenderID:        "Неверный формат идентификатора тендера.",
+		msgInvalidOrganizationID:  "Неверный формат идентификатора организации.",
+		msgInvalidExpectedVersion: "Некорректное значение ожидаемой версии.",
+		msgDecisionRequired:       "Согласовать или отклонить предложение можно только решением по нему.",
+		msgInvalidAuthHeader:      "Некорректный заголовок авторизации.",
+		msgInvalidIssuerKey:       "Неверный ключ для выпуска токена.",
+	},
+	langEnglish: {
+		CodeBadRequest:            "The request or its parameters are malformed.",
+		CodeValidationFailed:      "The data does not meet the requirements.",
+		CodeUnauthorized:          "Authorization is required.",
+		CodeInvalidToken:          "The token is invalid or expired.",
+		CodeUserNotFound:          "The user does not exist or is invalid.",
+		CodeForbidden:             "Not enough rights to perform the action.",
+		CodeBidTenderMismatch:     "The organization is not allowed to bid on this tender.",
+		CodeOrganizationNotFound:  "Organization not found.",
+		CodeTenderNotFound:        "Tender not found.",
+		CodeTenderVersionNotFound: "Tender version not found.",
+		CodeBidNotFound:           "Bid not found.",
+		CodeBidVersionNotFound:    "Bid version not found.",
+		CodeAuthorBidsNotFound:    "The author has no bids for this tender.",
+		CodeDecisionNotAllowed:    "The decision cannot be submitted.",
+		CodeTenderClosed:          "The tender is closed and no longer accepts bids.",
+		CodeDeadlinePassed:        "The submission deadline for the tender has passed.",
+		CodePublicationNotFound:   "The tender has no scheduled publication.",
+		CodePublicationNotAllowed: "Publication can be scheduled only for a tender that has not been published yet.",
+		CodeVersionConflict:       "The object has been modified by another user.",
+		CodeInvalidTransition:     "The requested status transition is not allowed.",
+		CodeInternal:              "Internal server error.",
+		codeNotFound:              "Route not found.",
+		codeMethodNotAllowed:      "Method not allowed.",
+
+		service.GuardDescriptionRequired:     "A tender without a description cannot be published.",
+		service.GuardPublishedTenderRequired: "A bid can be published only for a published tender.",
+		service.GuardFinalStatus:             "A canceled, approved or rejected bid cannot be changed.",
+
+		msgInvalidBody:            "The data is malformed or does not meet the requirements.",
+		msgInvalidTenderID:        "Invalid tender identifier format.",
+		msgInvalidOrganizationID:  "Invalid organization identifier format.",
+		msgInvalidExpectedVersion: "Invalid value of the expected version.",
+		msgDecisionRequired:       "A bid can be approved or rejected only by submitting a decision.",
+		msgInvalidAuthHeader:      "Invalid authorization header.",
+		msgInvalidIssuerKey:       "Invalid token issuer key.",
+	},
+}
+
+// translators переводит ошибки validator на поддерживаемые языки
+var translators = ut.New(ru.New(), ru.New(), en.New())
+
+// language выбирает язык ответа по заголовку Accept-Language с учетом q-значений
+func language(c *fiber.Ctx) string {
+	if lang := c.AcceptsLanguages(langRussian, langEnglish); lang != "" {
+		return lang
+	}
+	return defaultLanguage
+}
+
+// message возвращает текст по ключу на языке lang. Если перевода нет, используется язык по умолчанию
+func message(lang, key string) (string, bool) {
+	if text, ok := messages[lang][key]; ok {
+		return text, true
+	}
+	text, ok := messages[defaultLanguage][key]
+	return text, ok
+}
+
+// fieldErrors описывает каждое поле, не прошедшее проверку, сообщением на языке lang
+func fieldErrors(errs validator.ValidationErrors, lang string) []fiber.Map {
+	translator, _ := translators.GetTranslator(lang)
+
+	fields := make([]fiber.Map, 0, len(errs))
+	for _, fieldErr := range errs {
+		fields = append(fields, fiber.Map{
+			"field":   fieldErr.Field(),
+			"rule":    fieldErr.Tag(),
+			"message": fieldErr.Translate(translator),
+		})
+	}
+	return fields
+}
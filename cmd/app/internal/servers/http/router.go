@@ -2,32 +2,63 @@ package http
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"time"
+	"zadanie-6105/cmd/app/internal/auth"
 )
 
-func SetupRoutes(app *fiber.App) {
+// SetupHealthRoutes регистрирует проверки для оркестратора и балансировщика вне префикса /api и без авторизации
+func SetupHealthRoutes(app *fiber.App, checks []HealthCheck, timeout time.Duration) {
+	app.Get("/healthz", Liveness)
+
+	app.Get("/readyz", Readiness(checks, timeout))
+}
+
+func SetupRoutes(app *fiber.App, h *Handler, tokens *auth.TokenManager, authConfig auth.Config) {
 	app.Get("/api/ping", func(c *fiber.Ctx) error {
 		return c.SendString("ok")
 	})
 
-	app.Get("/api/tenders", GetTenders)
+	app.Post("/api/auth/token", IssueToken(tokens, h.users, authConfig.IssuerKey))
+
+	app.Use("/api", AuthMiddleware(tokens, h.users, authConfig.Legacy))
+
+	app.Get("/api/tenders", h.GetTenders)
+
+	app.Post("/api/tenders/new", h.CreateTender)
+
+	app.Get("/api/tenders/my", h.GetUserTenders)
+
+	app.Get("/api/tenders/scheduled", h.GetScheduledTenders)
+
+	app.Patch("/api/tenders/:tenderId/edit", h.UpdateTender)
+
+	app.Get("/api/tenders/:tenderId/status", h.GetTenderStatus)
+
+	app.Put("/api/tenders/:tenderId/status", h.UpdateTenderStatus)
+
+	app.Put("/api/tenders/:tenderId/rollback/:version", h.RollbackTender)
+
+	app.Get("/api/tenders/:tenderId/transitions", h.GetTenderTransitions)
+
+	app.Delete("/api/tenders/:tenderId/publication", h.CancelTenderPublication)
 
-	app.Post("/api/tenders/new", CreateTender)
+	app.Post("/api/bids/new", h.CreateBid)
 
-	app.Get("/api/tenders/my", GetUserTenders)
+	app.Get("/api/bids/my", h.GetUserBids)
 
-	app.Patch("/api/tenders/:tenderId/edit", UpdateTender)
+	app.Get("/api/bids/:tenderId/list", h.GetBidsForTender)
 
-	app.Get("/api/tenders/:tenderId/status", GetTenderStatus)
+	app.Get("/api/bids/:bidId/status", h.GetBidStatus)
 
-	app.Put("/api/tenders/:tenderId/status", UpdateTenderStatus)
+	app.Put("/api/bids/:bidId/status", h.UpdateBidStatus)
 
-	app.Put("/api/tenders/:tenderId/rollback/:version", RollbackTender)
+	app.Patch("/api/bids/:bidId/edit", h.EditBid)
 
-	app.Post("/api/bids/new", CreateBid)
+	app.Put("/api/bids/:bidId/rollback/:version", h.RollbackBid)
 
-	app.Get("/api/bids/my", GetUserBids)
+	app.Put("/api/bids/:bidId/submit_decision", h.SubmitBidDecision)
 
-	app.Get("/api/bids/:bidId/status", GetBidStatus)
+	app.Put("/api/bids/:bidId/feedback", h.SubmitBidFeedback)
 
-	app.Put("/api/bids/:bidId/status", UpdateBidStatus)
+	app.Get("/api/bids/:tenderId/reviews", h.GetBidReviews)
 }
@@ -1,29 +1,261 @@
 package http
 
 import (
+	"context"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
-	"github.com/joho/godotenv"
-	"log"
+	"gorm.io/gorm"
+	"log/slog"
 	"os"
+	"syscall"
+	"time"
+	"zadanie-6105/cmd/app/internal/auth"
+	"zadanie-6105/cmd/app/internal/config"
+	"zadanie-6105/cmd/app/internal/lifecycle"
+	"zadanie-6105/cmd/app/internal/logging"
+	"zadanie-6105/cmd/app/internal/metrics"
+	"zadanie-6105/cmd/app/internal/scheduler"
+	"zadanie-6105/cmd/app/internal/service"
+	"zadanie-6105/cmd/app/internal/storage"
+	"zadanie-6105/cmd/app/internal/storage/memory"
 	"zadanie-6105/cmd/app/internal/storage/postgresql"
 )
 
-func Run() {
-	err := godotenv.Load(".env")
+// Run поднимает хранилище, выбранное в конфигурации, и обслуживает HTTP-запросы до SIGINT или SIGTERM.
+// При остановке сервер дожидается обработки текущих запросов и закрывает соединения с базой
+func Run(conf config.Config) {
+	lc := lifecycle.New(syscall.SIGINT, syscall.SIGTERM)
+
+	// Вне режима совместимости пустой ключ подписи не проходит проверку конфигурации
+	if conf.Auth.Legacy && conf.Auth.Secret == "" {
+		slog.Warn("AUTH_SECRET не задан, в режиме совместимости токены будут недействительны после перезапуска")
+	}
+
+	tokens, err := auth.NewTokenManager(conf.Auth)
+	if err != nil {
+		logging.Fatal("Ошибка инициализации авторизации", err)
+	}
+
+	deps := Dependencies{
+		Tokens:  tokens,
+		Metrics: metrics.New(),
+	}
+	switch conf.Storage.Type {
+	case config.StoragePostgres:
+		queryLogger := logging.NewGormLogger(conf.Log.SQLLogLevel(), conf.Log.SQLSlowThreshold)
+		db, err := postgresql.Connect(conf.Database, conf.Pool, queryLogger)
+		if err != nil {
+			logging.Fatal("Ошибка подключения к базе данных", err)
+		}
+		if err := prepareSchema(db, conf.Database.MigrateOnStart); err != nil {
+			logging.Fatal("Ошибка проверки миграций", err)
+		}
+		lc.OnShutdown("database", func(context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.Close()
+		})
+		deps.HealthChecks, err = postgresChecks(db)
+		if err != nil {
+			logging.Fatal("Ошибка инициализации проверок готовности", err)
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			logging.Fatal("Ошибка получения пула соединений", err)
+		}
+		if err := deps.Metrics.RegisterDB(sqlDB); err != nil {
+			logging.Fatal("Ошибка регистрации метрик базы данных", err)
+		}
+		deps.Repositories = postgresql.NewRepositories(db)
+		deps.Transactor = postgresql.NewTransactor(db)
+		deps.Locker = postgresql.NewLocker(db)
+	case config.StorageMemory:
+		store := memory.New()
+		if conf.Storage.Seed != "" {
+			seed, err := os.Open(conf.Storage.Seed)
+			if err != nil {
+				logging.Fatal("Ошибка открытия файла с начальными данными", err)
+			}
+			err = store.LoadSeed(seed)
+			seed.Close()
+			if err != nil {
+				logging.Fatal("Ошибка загрузки начальных данных", err)
+			}
+		}
+		slog.Warn("Используется хранилище в памяти, данные не сохраняются между запусками")
+		deps.Repositories = store.Repositories()
+		deps.Transactor = store
+		deps.Locker = store
+	}
+
+	app := NewApp(conf, deps)
+	lc.Go("http", func(ctx context.Context) error {
+		return serve(ctx, app, conf.Server)
+	})
+
+	if conf.Scheduler.Interval > 0 {
+		tenders := service.NewTenderService(deps.Repositories, deps.Transactor, deps.events())
+		lc.Go("scheduler", scheduler.New(deps.Locker, conf.Scheduler.Interval, schedulerJobs(tenders)...).Run)
+	} else {
+		slog.Warn("Планировщик отключен, тендеры не будут публиковаться по расписанию и закрываться по сроку")
+	}
+
+	if err := lc.Wait(conf.Server.ShutdownTimeout); err != nil {
+		logging.Fatal("Приложение остановлено с ошибкой", err)
+	}
+	slog.Info("Приложение остановлено")
+}
+
+// serve принимает запросы до отмены ctx, после чего дожидается завершения текущих запросов
+func serve(ctx context.Context, app *fiber.App, conf config.Server) error {
+	listenErr := make(chan error, 1)
+	go func() {
+		slog.Info("Сервер запущен", "address", conf.Address)
+		listenErr <- app.Listen(conf.Address)
+	}()
+
+	select {
+	case err := <-listenErr:
+		return err
+	case <-ctx.Done():
+		return app.ShutdownWithTimeout(conf.ShutdownTimeout)
+	}
+}
+
+// schedulerJobs - фоновые задачи, которые планировщик выполняет на каждом запуске
+func schedulerJobs(tenders *service.TenderService) []scheduler.Job {
+	return []scheduler.Job{
+		{
+			Name: "close-expired-tenders",
+			Run: func(ctx context.Context, now time.Time) error {
+				closed, err := tenders.CloseExpired(ctx, now)
+				if closed > 0 {
+					slog.Info("Закрыты тендеры с истекшим сроком подачи предложений", "count", closed)
+				}
+				return err
+			},
+		},
+		{
+			Name: "publish-scheduled-tenders",
+			Run: func(ctx context.Context, now time.Time) error {
+				published, err := tenders.PublishScheduled(ctx, now)
+				if published > 0 {
+					slog.Info("Опубликованы тендеры по расписанию", "count", published)
+				}
+				return err
+			},
+		},
+	}
+}
+
+// prepareSchema применяет миграции при MIGRATE_ON_START=true, иначе только предупреждает о непримененных
+func prepareSchema(db *gorm.DB, migrateOnStart bool) error {
+	migrator, err := postgresql.NewMigrator(db)
 	if err != nil {
-		log.Fatalf("Ошибка загрузки .env файла: %v", err)
+		return err
 	}
-	postgresql.ConnectDb()
-	app := fiber.New()
-	app.Use(func(c *fiber.Ctx) error {
-		c.Locals("db", postgresql.DB.Db)
-		return c.Next()
+
+	ctx := context.Background()
+	if migrateOnStart {
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			slog.Info("Применена миграция", "version", migration.Version, "name", migration.Name)
+		}
+		return err
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		slog.Warn("Есть непримененные миграции, выполните migrate up", "pending", len(pending))
+	}
+	return nil
+}
+
+// postgresChecks проверяет доступность базы и то, что к ней применены все миграции
+func postgresChecks(db *gorm.DB) ([]HealthCheck, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := postgresql.NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return []HealthCheck{
+		{
+			Name:  "database",
+			Check: sqlDB.PingContext,
+		},
+		{
+			Name: "migrations",
+			Check: func(ctx context.Context) error {
+				pending, err := migrator.Pending(ctx)
+				if err != nil {
+					return err
+				}
+				if len(pending) > 0 {
+					return fmt.Errorf("%d pending migrations, next is %d_%s", len(pending), pending[0].Version, pending[0].Name)
+				}
+				return nil
+			},
+		},
+	}, nil
+}
+
+// Dependencies - компоненты, поверх которых собирается HTTP-приложение
+type Dependencies struct {
+	Repositories storage.Repositories
+	Transactor   storage.Transactor
+	// Locker нужен планировщику, чтобы задачу выполнял только один экземпляр приложения
+	Locker       storage.Locker
+	Tokens       *auth.TokenManager
+	HealthChecks []HealthCheck
+	// Metrics может быть nil, тогда /metrics не регистрируется
+	Metrics *metrics.Metrics
+}
+
+// events возвращает получателя бизнес-событий: метрики, если они включены
+func (d Dependencies) events() service.EventRecorder {
+	if d.Metrics == nil {
+		return nil
+	}
+	return d.Metrics
+}
+
+// NewApp собирает сервисы и маршруты поверх переданного хранилища
+func NewApp(conf config.Config, deps Dependencies) *fiber.App {
+	events := deps.events()
+
+	handler := NewHandler(
+		service.NewTenderService(deps.Repositories, deps.Transactor, events),
+		service.NewBidService(deps.Repositories, deps.Transactor, events),
+		service.NewUserService(deps.Repositories.Employees),
+	)
+
+	// Immutable: строки из запроса не должны переиспользоваться fiber, их может сохранить хранилище в памяти
+	app := fiber.New(fiber.Config{
+		Immutable:             true,
+		DisableStartupMessage: true,
+		ErrorHandler:          ErrorHandler,
+		ReadTimeout:           conf.Server.ReadTimeout,
+		WriteTimeout:          conf.Server.WriteTimeout,
+		IdleTimeout:           conf.Server.IdleTimeout,
 	})
-	SetupRoutes(app)
-	serverAddress := os.Getenv("SERVER_ADDRESS")
-	if serverAddress == "" {
-		serverAddress = ":8080"
+	app.Use(RequestContext(slog.Default()))
+	app.Use(AccessLog())
+	if deps.Metrics != nil {
+		app.Use(deps.Metrics.Middleware())
+		app.Get("/metrics", deps.Metrics.Handler())
 	}
-	log.Printf("Сервер запущен на адресе %s", serverAddress)
-	log.Fatal(app.Listen(serverAddress))
+	app.Use(HandleErrors())
+	SetupHealthRoutes(app, deps.HealthChecks, conf.Server.ReadinessTimeout)
+	SetupRoutes(app, handler, deps.Tokens, conf.Auth)
+	return app
 }
@@ -0,0 +1,162 @@
+package http
+
+import (
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	ruTranslations "github.com/go-playground/validator/v10/translations/ru"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"slices"
+	"strings"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+var validate = newValidator()
+
+// Значения перечислений из спецификации в том виде, в котором они указаны в ней
+var (
+	serviceTypes   = []string{models2.ServiceTypeConstruction, models2.ServiceTypeDelivery, models2.ServiceTypeManufacture}
+	tenderStatuses = []string{"Created", "Published", "Closed"}
+	bidStatuses    = []string{"Created", "Published", "Canceled", "Approved", "Rejected"}
+	bidDecisions   = []string{"Approved", "Rejected"}
+)
+
+// enumValidators - собственные правила validator для перечислений спецификации.
+// Статусы и решения сравниваются без учета регистра, виды услуг - точно
+var enumValidators = []struct {
+	tag        string
+	values     []string
+	ignoreCase bool
+}{
+	{tag: "service_type", values: serviceTypes},
+	{tag: "tender_status", values: tenderStatuses, ignoreCase: true},
+	{tag: "bid_status", values: bidStatuses, ignoreCase: true},
+	{tag: "bid_decision", values: bidDecisions, ignoreCase: true},
+}
+
+// Ограничения суммы: столько знаков помещается в NUMERIC(20,4)
+const (
+	moneyScale         = 4
+	moneyIntegerDigits = 16
+)
+
+// ruleTranslations - сообщения для правил, у которых нет перевода в пакетах validator
+var ruleTranslations = []struct {
+	tag     string
+	russian string
+	english string
+}{
+	{
+		tag:     "iso4217",
+		russian: "{0} должен быть кодом валюты ISO 4217",
+		english: "{0} must be an ISO 4217 currency code",
+	},
+	{
+		tag:     "required_with",
+		russian: "{0} обязательное поле",
+		english: "{0} is a required field",
+	},
+	{
+		tag:     "money_amount",
+		russian: "{0} должна быть положительной суммой не более чем с 4 знаками после запятой",
+		english: "{0} must be a positive amount with at most 4 decimal places",
+	},
+}
+
+// newValidator настраивает validator: имена полей в ошибках берутся из тегов json и query, как их видит клиент,
+// регистрируются правила для перечислений и переводы сообщений на поддерживаемые языки
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "query"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	// Суммы проверяются как строки: правила validator не умеют работать с decimal.Decimal напрямую
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		if amount, ok := field.Interface().(decimal.Decimal); ok {
+			return amount.String()
+		}
+		return nil
+	}, decimal.Decimal{})
+	mustRegister(v.RegisterValidation("money_amount", moneyAmount))
+
+	ruTranslator, _ := translators.GetTranslator(langRussian)
+	enTranslator, _ := translators.GetTranslator(langEnglish)
+	mustRegister(ruTranslations.RegisterDefaultTranslations(v, ruTranslator))
+	mustRegister(enTranslations.RegisterDefaultTranslations(v, enTranslator))
+
+	for _, enum := range enumValidators {
+		mustRegister(v.RegisterValidation(enum.tag, enumValidator(enum.values, enum.ignoreCase)))
+
+		allowed := strings.Join(enum.values, ", ")
+		mustRegister(v.RegisterTranslation(enum.tag, ruTranslator,
+			registerTranslation(enum.tag, "{0} должен принимать одно из значений: "+allowed),
+			translateField))
+		mustRegister(v.RegisterTranslation(enum.tag, enTranslator,
+			registerTranslation(enum.tag, "{0} must be one of: "+allowed),
+			translateField))
+	}
+
+	for _, rule := range ruleTranslations {
+		mustRegister(v.RegisterTranslation(rule.tag, ruTranslator, registerTranslation(rule.tag, rule.russian), translateField))
+		mustRegister(v.RegisterTranslation(rule.tag, enTranslator, registerTranslation(rule.tag, rule.english), translateField))
+	}
+
+	return v
+}
+
+// enumValidator проверяет, что строковое поле равно одному из values
+func enumValidator(values []string, ignoreCase bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		return slices.ContainsFunc(values, func(allowed string) bool {
+			if ignoreCase {
+				return strings.EqualFold(allowed, value)
+			}
+			return allowed == value
+		})
+	}
+}
+
+// moneyAmount проверяет, что сумма положительна и помещается в колонку NUMERIC(20,4) без округления
+func moneyAmount(fl validator.FieldLevel) bool {
+	amount, err := decimal.NewFromString(fl.Field().String())
+	if err != nil || !amount.IsPositive() {
+		return false
+	}
+	if !amount.Equal(amount.Truncate(moneyScale)) {
+		return false
+	}
+	return amount.Truncate(0).NumDigits() <= moneyIntegerDigits
+}
+
+func registerTranslation(tag, text string) validator.RegisterTranslationsFunc {
+	return func(translator ut.Translator) error {
+		return translator.Add(tag, text, true)
+	}
+}
+
+func translateField(translator ut.Translator, fieldErr validator.FieldError) string {
+	text, err := translator.T(fieldErr.Tag(), fieldErr.Field())
+	if err != nil {
+		return fieldErr.Error()
+	}
+	return text
+}
+
+// mustRegister останавливает запуск, если правило или перевод не зарегистрировались: это ошибка в коде, а не в данных
+func mustRegister(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
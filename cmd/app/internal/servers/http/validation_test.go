@@ -0,0 +1,136 @@
+package http
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+	"testing"
+)
+
+type statusQuery struct {
+	TenderStatus string `query:"tenderStatus" validate:"omitempty,tender_status"`
+	BidStatus    string `query:"bidStatus" validate:"omitempty,bid_status"`
+	ServiceType  string `query:"serviceType" validate:"omitempty,service_type"`
+}
+
+type budgetQuery struct {
+	BudgetMin      string `query:"budgetMin" validate:"omitempty,numeric"`
+	BudgetCurrency string `query:"budgetCurrency" validate:"required_with=BudgetMin,omitempty,iso4217"`
+}
+
+func TestValidationMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		field   string
+		rule    string
+		russian string
+		english string
+	}{
+		{
+			name:    "tender status",
+			value:   statusQuery{TenderStatus: "Open"},
+			field:   "tenderStatus",
+			rule:    "tender_status",
+			russian: "tenderStatus должен принимать одно из значений: Created, Published, Closed",
+			english: "tenderStatus must be one of: Created, Published, Closed",
+		},
+		{
+			name:    "bid status",
+			value:   statusQuery{BidStatus: "Done"},
+			field:   "bidStatus",
+			rule:    "bid_status",
+			russian: "bidStatus должен принимать одно из значений: Created, Published, Canceled, Approved, Rejected",
+			english: "bidStatus must be one of: Created, Published, Canceled, Approved, Rejected",
+		},
+		{
+			name:    "service type is case sensitive",
+			value:   statusQuery{ServiceType: "delivery"},
+			field:   "serviceType",
+			rule:    "service_type",
+			russian: "serviceType должен принимать одно из значений: Construction, Delivery, Manufacture",
+			english: "serviceType must be one of: Construction, Delivery, Manufacture",
+		},
+		{
+			name:    "money amount scale",
+			value:   moneyRequest{Amount: decimal.RequireFromString("1.12345"), Currency: "RUB"},
+			field:   "amount",
+			rule:    "money_amount",
+			russian: "amount должна быть положительной суммой не более чем с 4 знаками после запятой",
+			english: "amount must be a positive amount with at most 4 decimal places",
+		},
+		{
+			name:    "money amount sign",
+			value:   moneyRequest{Amount: decimal.RequireFromString("-1"), Currency: "RUB"},
+			field:   "amount",
+			rule:    "money_amount",
+			russian: "amount должна быть положительной суммой не более чем с 4 знаками после запятой",
+			english: "amount must be a positive amount with at most 4 decimal places",
+		},
+		{
+			name:    "money amount integer digits",
+			value:   moneyRequest{Amount: decimal.RequireFromString("12345678901234567"), Currency: "RUB"},
+			field:   "amount",
+			rule:    "money_amount",
+			russian: "amount должна быть положительной суммой не более чем с 4 знаками после запятой",
+			english: "amount must be a positive amount with at most 4 decimal places",
+		},
+		{
+			name:    "currency code",
+			value:   moneyRequest{Amount: decimal.NewFromInt(1), Currency: "XXY"},
+			field:   "currency",
+			rule:    "iso4217",
+			russian: "currency должен быть кодом валюты ISO 4217",
+			english: "currency must be an ISO 4217 currency code",
+		},
+		{
+			name:    "budget bound without currency",
+			value:   budgetQuery{BudgetMin: "10"},
+			field:   "budgetCurrency",
+			rule:    "required_with",
+			russian: "budgetCurrency обязательное поле",
+			english: "budgetCurrency is a required field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs validator.ValidationErrors
+			if err := validate.Struct(tt.value); !errors.As(err, &errs) {
+				t.Fatalf("Struct() error = %v, want validation errors", err)
+			}
+
+			for lang, want := range map[string]string{langRussian: tt.russian, langEnglish: tt.english} {
+				fields := fieldErrors(errs, lang)
+				if len(fields) != 1 {
+					t.Fatalf("%s fields = %v, want one field", lang, fields)
+				}
+				if fields[0]["field"] != tt.field || fields[0]["rule"] != tt.rule || fields[0]["message"] != want {
+					t.Errorf("%s field = %v, want field %q rule %q message %q", lang, fields[0], tt.field, tt.rule, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationAcceptsValidValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{name: "statuses in any casing", value: statusQuery{TenderStatus: "published", BidStatus: "CANCELED", ServiceType: "Delivery"}},
+		{name: "empty optional fields", value: statusQuery{}},
+		{name: "money with four decimals", value: moneyRequest{Amount: decimal.RequireFromString("1500.1234"), Currency: "RUB"}},
+		{name: "largest money amount", value: moneyRequest{Amount: decimal.RequireFromString("9999999999999999.9999"), Currency: "USD"}},
+		{name: "budget bound with currency", value: budgetQuery{BudgetMin: "10", BudgetCurrency: "EUR"}},
+		{name: "currency without bounds", value: budgetQuery{BudgetCurrency: "EUR"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validate.Struct(tt.value); err != nil {
+				t.Fatalf("Struct() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,581 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+// bidDecisionQuorum - число одобрений, после которого предложение считается согласованным
+const bidDecisionQuorum = 3
+
+// GuardPublishedTenderRequired - предложение можно опубликовать только по опубликованному тендеру
+const GuardPublishedTenderRequired = "PUBLISHED_TENDER_REQUIRED"
+
+// GuardSubmissionDeadline - предложение нельзя опубликовать после срока подачи предложений по тендеру
+const GuardSubmissionDeadline = "SUBMISSION_DEADLINE"
+
+// GuardFinalStatus - предложение в итоговом статусе нельзя править и откатывать
+const GuardFinalStatus = "FINAL_STATUS"
+
+// bidTransition - предложение и его тендер на момент now: проверки переходов предложения зависят от тендера
+type bidTransition struct {
+	bid    models2.Bid
+	tender models2.Tender
+	now    time.Time
+}
+
+// bidStateMachine - допустимые переходы статусов предложения. Отмененное, согласованное и отклоненное
+// предложения больше не меняют статус
+var bidStateMachine = stateMachine[models2.BidStatusType, bidTransition]{
+	transitions: map[models2.BidStatusType][]models2.BidStatusType{
+		models2.BidStatusCreated: {models2.BidStatusPublished, models2.BidStatusCanceled},
+		models2.BidStatusPublished: {
+			models2.BidStatusCanceled,
+			models2.BidStatusApproved,
+			models2.BidStatusRejected,
+		},
+	},
+	guards: map[models2.BidStatusType][]transitionGuard[bidTransition]{
+		models2.BidStatusPublished: {
+			{
+				name: GuardPublishedTenderRequired,
+				allow: func(transition bidTransition) bool {
+					return transition.tender.Status == models2.TenderStatusPublished
+				},
+			},
+			{
+				// Тендер с истекшим сроком закрывает планировщик, до этого публикация уже не принимается
+				name: GuardSubmissionDeadline,
+				allow: func(transition bidTransition) bool {
+					return !deadlinePassed(transition.tender, transition.now)
+				},
+				err: ErrSubmissionDeadlinePassed,
+			},
+		},
+	},
+}
+
+// BidService - бизнес-правила предложений. Методы изменения принимают expectedVersion - версию, которую видел клиент.
+// Если предложение успело измениться, возвращается VersionConflictError, нулевое значение отключает проверку
+type BidService struct {
+	bids          storage.BidRepository
+	tenders       storage.TenderRepository
+	organizations storage.OrganizationRepository
+	reviews       storage.ReviewRepository
+	transactor    storage.Transactor
+	events        EventRecorder
+}
+
+// NewBidService создает сервис предложений. Изменения предложений выполняются в транзакциях transactor,
+// чтение - через переданные репозитории. events может быть nil
+func NewBidService(repositories storage.Repositories, transactor storage.Transactor, events EventRecorder) *BidService {
+	return &BidService{
+		bids:          repositories.Bids,
+		tenders:       repositories.Tenders,
+		organizations: repositories.Organizations,
+		reviews:       repositories.Reviews,
+		transactor:    transactor,
+		events:        recorderOrNoop(events),
+	}
+}
+
+type CreateBidInput struct {
+	Name           string
+	Description    string
+	TenderID       uuid.UUID
+	OrganizationID uuid.UUID
+	// Price - цена предложения, нулевое значение означает, что цена не указана
+	Price models2.Money
+}
+
+// BidPatch содержит новые значения полей предложения, nil означает отсутствие изменений
+type BidPatch struct {
+	Name        *string
+	Description *string
+	Price       *models2.Money
+}
+
+func (s *BidService) Create(ctx context.Context, actor models2.Employee, input CreateBidInput) (models2.Bid, error) {
+	bid := models2.Bid{
+		ID:              uuid.New(),
+		Name:            input.Name,
+		Description:     input.Description,
+		Status:          models2.BidStatusCreated,
+		TenderID:        input.TenderID,
+		OrganizationID:  input.OrganizationID,
+		Version:         1,
+		CreatorUsername: actor.Username,
+		Price:           input.Price,
+	}
+
+	err := s.transactor.WithinTransaction(ctx, func(repositories storage.Repositories) error {
+		tender, err := getTender(ctx, repositories.Tenders, input.TenderID)
+		if err != nil {
+			return err
+		}
+
+		if tender.OrganizationID != input.OrganizationID {
+			return ErrBidTenderMismatch
+		}
+
+		if tender.Status == models2.TenderStatusClosed {
+			return ErrTenderClosed
+		}
+
+		if deadlinePassed(tender, time.Now()) {
+			return ErrSubmissionDeadlinePassed
+		}
+
+		if err := repositories.Bids.Create(ctx, &bid); err != nil {
+			return fmt.Errorf("create bid: %w", err)
+		}
+
+		if err := repositories.Bids.CreateVersion(ctx, newBidVersion(bid)); err != nil {
+			return fmt.Errorf("create bid version: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models2.Bid{}, err
+	}
+
+	s.events.BidSubmitted()
+	return bid, nil
+}
+
+func (s *BidService) ListByUser(ctx context.Context, actor models2.Employee, limit, offset int) ([]models2.Bid, error) {
+	bids, err := s.bids.List(ctx, storage.BidFilter{
+		CreatorUsername: actor.Username,
+		Limit:           limit,
+		Offset:          offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list bids: %w", err)
+	}
+	return bids, nil
+}
+
+// ListForTender возвращает предложения по тендеру. Ответственные за организацию тендера видят все предложения,
+// остальные - только опубликованные
+func (s *BidService) ListForTender(ctx context.Context, actor models2.Employee, tenderID uuid.UUID, limit, offset int) ([]models2.Bid, error) {
+	tender, err := getTender(ctx, s.tenders, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := storage.BidFilter{
+		TenderID: tender.ID,
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	isResponsible, err := s.organizations.IsResponsible(ctx, tender.OrganizationID, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("check organization responsibility: %w", err)
+	}
+	if !isResponsible {
+		filter.Status = models2.BidStatusPublished
+	}
+
+	bids, err := s.bids.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list bids: %w", err)
+	}
+	return bids, nil
+}
+
+func (s *BidService) GetStatus(ctx context.Context, actor models2.Employee, bidID uuid.UUID) (models2.BidStatusType, error) {
+	bid, err := getBid(ctx, s.bids, bidID)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.organizations.GetByID(ctx, bid.OrganizationID); err != nil {
+		return "", notFound(err, ErrOrganizationNotFound, "get organization")
+	}
+
+	if err := requireResponsible(ctx, s.organizations, actor.ID, bid.OrganizationID); err != nil {
+		return "", err
+	}
+
+	return bid.Status, nil
+}
+
+func (s *BidService) UpdateStatus(
+	ctx context.Context,
+	actor models2.Employee,
+	bidID uuid.UUID,
+	status models2.BidStatusType,
+	expectedVersion int,
+) (models2.Bid, error) {
+	var bid models2.Bid
+	err := s.transactor.WithinTransaction(ctx, func(repositories storage.Repositories) error {
+		var (
+			tender models2.Tender
+			err    error
+		)
+		bid, tender, err = lockBidWithTender(ctx, repositories, bidID)
+		if err != nil {
+			return err
+		}
+
+		if err := requireResponsible(ctx, repositories.Organizations, actor.ID, bid.OrganizationID); err != nil {
+			return err
+		}
+
+		if err := checkVersion(expectedVersion, bid.Version); err != nil {
+			return err
+		}
+
+		return transitionBid(ctx, repositories.Bids, &bid, tender, status)
+	})
+	if err != nil {
+		return models2.Bid{}, err
+	}
+
+	return bid, nil
+}
+
+func (s *BidService) Edit(
+	ctx context.Context,
+	actor models2.Employee,
+	bidID uuid.UUID,
+	patch BidPatch,
+	expectedVersion int,
+) (models2.Bid, error) {
+	return s.updateAsEditor(ctx, actor, bidID, expectedVersion, func(repositories storage.Repositories, bid *models2.Bid) error {
+		isUpdated := false
+		if patch.Name != nil && *patch.Name != bid.Name {
+			bid.Name = *patch.Name
+			isUpdated = true
+		}
+		if patch.Description != nil && *patch.Description != bid.Description {
+			bid.Description = *patch.Description
+			isUpdated = true
+		}
+		if patch.Price != nil && !patch.Price.Equal(bid.Price) {
+			bid.Price = *patch.Price
+			isUpdated = true
+		}
+
+		if !isUpdated {
+			return nil
+		}
+
+		return appendBidVersion(ctx, repositories.Bids, bid)
+	})
+}
+
+// Rollback восстанавливает название, описание и цену предложения из версии и сохраняет результат как новую версию
+func (s *BidService) Rollback(
+	ctx context.Context,
+	actor models2.Employee,
+	bidID uuid.UUID,
+	version int,
+	expectedVersion int,
+) (models2.Bid, error) {
+	return s.updateAsEditor(ctx, actor, bidID, expectedVersion, func(repositories storage.Repositories, bid *models2.Bid) error {
+		bidVersion, err := repositories.Bids.GetVersion(ctx, bid.ID, version)
+		if err != nil {
+			return notFound(err, ErrBidVersionNotFound, "get bid version")
+		}
+
+		bid.Name = bidVersion.Name
+		bid.Description = bidVersion.Description
+		bid.Price = bidVersion.Price
+
+		return appendBidVersion(ctx, repositories.Bids, bid)
+	})
+}
+
+// SubmitDecision сохраняет решение ответственного. Одно отклонение отклоняет предложение,
+// для согласования нужен кворум min(3, число ответственных), после согласования тендер закрывается
+func (s *BidService) SubmitDecision(ctx context.Context, actor models2.Employee, bidID uuid.UUID, decision models2.BidDecisionType) (models2.Bid, error) {
+	var bid models2.Bid
+	err := s.transactor.WithinTransaction(ctx, func(repositories storage.Repositories) error {
+		var (
+			tender models2.Tender
+			err    error
+		)
+		bid, tender, err = lockBidWithTender(ctx, repositories, bidID)
+		if err != nil {
+			return err
+		}
+
+		if err := requireResponsible(ctx, repositories.Organizations, actor.ID, tender.OrganizationID); err != nil {
+			return err
+		}
+
+		if bid.Status != models2.BidStatusPublished || tender.Status == models2.TenderStatusClosed {
+			return ErrDecisionNotAllowed
+		}
+
+		bidDecision, err := repositories.Bids.GetDecision(ctx, bid.ID, actor.ID)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			bidDecision = models2.BidDecision{
+				ID:     uuid.New(),
+				BidID:  bid.ID,
+				UserID: actor.ID,
+			}
+		case err != nil:
+			return fmt.Errorf("get bid decision: %w", err)
+		}
+
+		bidDecision.Decision = decision
+		if err := repositories.Bids.SaveDecision(ctx, &bidDecision); err != nil {
+			return fmt.Errorf("save bid decision: %w", err)
+		}
+
+		if decision == models2.BidDecisionRejected {
+			return transitionBid(ctx, repositories.Bids, &bid, tender, models2.BidStatusRejected)
+		}
+
+		approvals, err := repositories.Bids.CountDecisions(ctx, bid.ID, models2.BidDecisionApproved)
+		if err != nil {
+			return fmt.Errorf("count bid decisions: %w", err)
+		}
+
+		responsibles, err := repositories.Organizations.CountResponsibles(ctx, tender.OrganizationID)
+		if err != nil {
+			return fmt.Errorf("count organization responsibles: %w", err)
+		}
+
+		if approvals < min(responsibles, bidDecisionQuorum) {
+			return nil
+		}
+
+		if err := transitionBid(ctx, repositories.Bids, &bid, tender, models2.BidStatusApproved); err != nil {
+			return err
+		}
+
+		// После согласования предложения тендер закрывается, остальные открытые предложения по нему отменяются
+		return transitionTender(ctx, repositories, &tender, models2.TenderStatusClosed, actor.Username)
+	})
+	if err != nil {
+		return models2.Bid{}, err
+	}
+
+	s.events.BidDecisionMade(decision)
+	return bid, nil
+}
+
+// SubmitFeedback сохраняет отзыв ответственного за организацию тендера на предложение
+func (s *BidService) SubmitFeedback(ctx context.Context, actor models2.Employee, bidID uuid.UUID, feedback string) (models2.Bid, error) {
+	bid, err := getBid(ctx, s.bids, bidID)
+	if err != nil {
+		return models2.Bid{}, err
+	}
+
+	tender, err := getTender(ctx, s.tenders, bid.TenderID)
+	if err != nil {
+		return models2.Bid{}, err
+	}
+
+	if err := requireResponsible(ctx, s.organizations, actor.ID, tender.OrganizationID); err != nil {
+		return models2.Bid{}, err
+	}
+
+	review := models2.Review{
+		ID:             uuid.New(),
+		BidID:          bid.ID,
+		AuthorUsername: actor.Username,
+		OrganizationID: tender.OrganizationID,
+		Comment:        feedback,
+	}
+
+	if err := s.reviews.Create(ctx, &review); err != nil {
+		return models2.Bid{}, fmt.Errorf("create review: %w", err)
+	}
+
+	return bid, nil
+}
+
+// ListReviews возвращает отзывы на предложения автора, который сделал предложение на тендер ответственного
+func (s *BidService) ListReviews(ctx context.Context, actor models2.Employee, tenderID uuid.UUID, authorUsername string, limit, offset int) ([]models2.Review, error) {
+	tender, err := getTender(ctx, s.tenders, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireResponsible(ctx, s.organizations, actor.ID, tender.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	authorBids, err := s.bids.Count(ctx, storage.BidFilter{
+		TenderID:        tender.ID,
+		CreatorUsername: authorUsername,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("count author bids: %w", err)
+	}
+	if authorBids == 0 {
+		return nil, ErrAuthorBidsNotFound
+	}
+
+	reviews, err := s.reviews.ListByBidAuthor(ctx, authorUsername, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+// updateAsEditor блокирует предложение, проверяет, что пользователь - его автор или ответственный за организацию,
+// а предложение еще не в итоговом статусе, сверяет ожидаемую версию и применяет apply в одной транзакции
+func (s *BidService) updateAsEditor(
+	ctx context.Context,
+	actor models2.Employee,
+	bidID uuid.UUID,
+	expectedVersion int,
+	apply func(repositories storage.Repositories, bid *models2.Bid) error,
+) (models2.Bid, error) {
+	var bid models2.Bid
+	err := s.transactor.WithinTransaction(ctx, func(repositories storage.Repositories) error {
+		var err error
+		bid, err = repositories.Bids.GetByIDForUpdate(ctx, bidID)
+		if err != nil {
+			return notFound(err, ErrBidNotFound, "get bid")
+		}
+
+		if bid.CreatorUsername != actor.Username {
+			if err := requireResponsible(ctx, repositories.Organizations, actor.ID, bid.OrganizationID); err != nil {
+				return err
+			}
+		}
+
+		if err := checkVersion(expectedVersion, bid.Version); err != nil {
+			return err
+		}
+
+		if bidStateMachine.Final(bid.Status) {
+			return &TransitionError{
+				From:    bid.Status,
+				To:      bid.Status,
+				Allowed: bidStateMachine.Allowed(bid.Status),
+				Guard:   GuardFinalStatus,
+			}
+		}
+
+		return apply(repositories, &bid)
+	})
+	if err != nil {
+		return models2.Bid{}, err
+	}
+
+	return bid, nil
+}
+
+func getBid(ctx context.Context, bids storage.BidRepository, bidID uuid.UUID) (models2.Bid, error) {
+	bid, err := bids.GetByID(ctx, bidID)
+	if err != nil {
+		return models2.Bid{}, notFound(err, ErrBidNotFound, "get bid")
+	}
+	return bid, nil
+}
+
+// lockBidWithTender блокирует тендер предложения, а затем само предложение. Закрытие тендера блокирует строки
+// в том же порядке, поэтому изменение статуса предложения и отмена предложений при закрытии не взаимоблокируются
+func lockBidWithTender(ctx context.Context, repositories storage.Repositories, bidID uuid.UUID) (models2.Bid, models2.Tender, error) {
+	bid, err := getBid(ctx, repositories.Bids, bidID)
+	if err != nil {
+		return models2.Bid{}, models2.Tender{}, err
+	}
+
+	tender, err := repositories.Tenders.GetByIDForUpdate(ctx, bid.TenderID)
+	if err != nil {
+		return models2.Bid{}, models2.Tender{}, notFound(err, ErrTenderNotFound, "get tender")
+	}
+
+	bid, err = repositories.Bids.GetByIDForUpdate(ctx, bidID)
+	if err != nil {
+		return models2.Bid{}, models2.Tender{}, notFound(err, ErrBidNotFound, "get bid")
+	}
+
+	return bid, tender, nil
+}
+
+// transitionBid переводит предложение в статус to по таблице переходов. Переход в текущий статус ничего не меняет
+func transitionBid(
+	ctx context.Context,
+	bids storage.BidRepository,
+	bid *models2.Bid,
+	tender models2.Tender,
+	to models2.BidStatusType,
+) error {
+	if bid.Status == to {
+		return nil
+	}
+
+	if err := bidStateMachine.Check(bidTransition{bid: *bid, tender: tender, now: time.Now()}, bid.Status, to); err != nil {
+		return err
+	}
+
+	return setBidStatus(ctx, bids, bid, to)
+}
+
+// cancelOpenBids отменяет предложения тендера, по которым еще не принято решение
+func cancelOpenBids(ctx context.Context, bids storage.BidRepository, tender models2.Tender) error {
+	tenderBids, err := bids.List(ctx, storage.BidFilter{TenderID: tender.ID, Limit: -1})
+	if err != nil {
+		return fmt.Errorf("list tender bids: %w", err)
+	}
+
+	for _, bid := range tenderBids {
+		if bid.Status != models2.BidStatusCreated && bid.Status != models2.BidStatusPublished {
+			continue
+		}
+		if err := transitionBid(ctx, bids, &bid, tender, models2.BidStatusCanceled); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setBidStatus меняет статус предложения и сохраняет результат как новую версию, как setTenderStatus
+func setBidStatus(ctx context.Context, bids storage.BidRepository, bid *models2.Bid, status models2.BidStatusType) error {
+	bid.Status = status
+	return appendBidVersion(ctx, bids, bid)
+}
+
+// appendBidVersion сохраняет текущее состояние предложения как следующую версию
+func appendBidVersion(ctx context.Context, bids storage.BidRepository, bid *models2.Bid) error {
+	nextVersion := bid.Version + 1
+	latestVersion, err := bids.GetLatestVersion(ctx, bid.ID)
+	switch {
+	case err == nil:
+		nextVersion = latestVersion.Version + 1
+	case !errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("get latest bid version: %w", err)
+	}
+
+	bid.Version = nextVersion
+	if err := bids.CreateVersion(ctx, newBidVersion(*bid)); err != nil {
+		return fmt.Errorf("create bid version: %w", err)
+	}
+
+	if err := bids.Update(ctx, bid); err != nil {
+		return fmt.Errorf("update bid: %w", err)
+	}
+
+	return nil
+}
+
+func newBidVersion(bid models2.Bid) *models2.BidVersion {
+	return &models2.BidVersion{
+		ID:          uuid.New(),
+		BidID:       bid.ID,
+		Version:     bid.Version,
+		Name:        bid.Name,
+		Description: bid.Description,
+		Status:      bid.Status,
+		CreatedAt:   time.Now(),
+		Price:       bid.Price,
+	}
+}
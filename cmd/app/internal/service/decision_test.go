@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage/memory"
+)
+
+// decide отправляет решения ответственных по очереди и возвращает статус предложения после каждого из них
+func decide(
+	t *testing.T,
+	store *memory.Store,
+	bid models2.Bid,
+	voters []models2.Employee,
+	decision models2.BidDecisionType,
+) []models2.BidStatusType {
+	t.Helper()
+
+	bids := NewBidService(store.Repositories(), store, nil)
+	statuses := make([]models2.BidStatusType, 0, len(voters))
+	for _, voter := range voters {
+		updated, err := bids.SubmitDecision(context.Background(), voter, bid.ID, decision)
+		if err != nil {
+			t.Fatalf("SubmitDecision(%s) error = %v", voter.Username, err)
+		}
+		statuses = append(statuses, updated.Status)
+	}
+	return statuses
+}
+
+func TestSubmitDecisionQuorum(t *testing.T) {
+	tests := []struct {
+		name string
+		// extraResponsibles - ответственные в дополнение к testResponsible
+		extraResponsibles int
+		// voters - сколько ответственных по очереди одобряют предложение
+		voters int
+		want   []models2.BidStatusType
+	}{
+		{
+			name:   "single responsible approves alone",
+			voters: 1,
+			want:   []models2.BidStatusType{models2.BidStatusApproved},
+		},
+		{
+			name:              "two responsibles need both approvals",
+			extraResponsibles: 1,
+			voters:            2,
+			want:              []models2.BidStatusType{models2.BidStatusPublished, models2.BidStatusApproved},
+		},
+		{
+			name:              "quorum is capped at three",
+			extraResponsibles: 4,
+			voters:            3,
+			want: []models2.BidStatusType{
+				models2.BidStatusPublished,
+				models2.BidStatusPublished,
+				models2.BidStatusApproved,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			responsibles := addResponsibles(t, store, tt.extraResponsibles)
+			bid := publishTestBid(t, store, publishTestTender(t, store).ID)
+
+			got := decide(t, store, bid, responsibles[:tt.voters], models2.BidDecisionApproved)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("statuses after approvals = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSubmitDecisionRepeatedVoteIsNotCounted(t *testing.T) {
+	store := newTestStore(t)
+	responsibles := addResponsibles(t, store, 1)
+	bid := publishTestBid(t, store, publishTestTender(t, store).ID)
+
+	got := decide(t, store, bid, []models2.Employee{responsibles[0], responsibles[0]}, models2.BidDecisionApproved)
+	if got[1] != models2.BidStatusPublished {
+		t.Fatalf("status after repeated approval = %s, want %s", got[1], models2.BidStatusPublished)
+	}
+
+	got = decide(t, store, bid, responsibles[1:], models2.BidDecisionApproved)
+	if got[0] != models2.BidStatusApproved {
+		t.Fatalf("status after second responsible = %s, want %s", got[0], models2.BidStatusApproved)
+	}
+}
+
+func TestSubmitDecisionSingleRejectionRejects(t *testing.T) {
+	store := newTestStore(t)
+	responsibles := addResponsibles(t, store, 2)
+	tender := publishTestTender(t, store)
+	bid := publishTestBid(t, store, tender.ID)
+
+	decide(t, store, bid, responsibles[:2], models2.BidDecisionApproved)
+	got := decide(t, store, bid, responsibles[2:], models2.BidDecisionRejected)
+	if got[0] != models2.BidStatusRejected {
+		t.Fatalf("status after rejection = %s, want %s", got[0], models2.BidStatusRejected)
+	}
+
+	// Отклонение не закрывает тендер: по нему можно согласовать другое предложение
+	current, err := store.Repositories().Tenders.GetByID(context.Background(), tender.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Status != models2.TenderStatusPublished {
+		t.Fatalf("tender status = %s, want %s", current.Status, models2.TenderStatusPublished)
+	}
+
+	_, err = NewBidService(store.Repositories(), store, nil).
+		SubmitDecision(context.Background(), testResponsible, bid.ID, models2.BidDecisionApproved)
+	if !errors.Is(err, ErrDecisionNotAllowed) {
+		t.Fatalf("decision on rejected bid error = %v, want %v", err, ErrDecisionNotAllowed)
+	}
+}
+
+func TestSubmitDecisionApprovalClosesTender(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	tender := publishTestTender(t, store)
+	approved := publishTestBid(t, store, tender.ID)
+	published := publishTestBid(t, store, tender.ID)
+	created := createTestBid(t, store, tender.ID)
+
+	decide(t, store, approved, []models2.Employee{testResponsible}, models2.BidDecisionApproved)
+
+	repositories := store.Repositories()
+	current, err := repositories.Tenders.GetByID(ctx, tender.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Status != models2.TenderStatusClosed {
+		t.Fatalf("tender status = %s, want %s", current.Status, models2.TenderStatusClosed)
+	}
+
+	want := map[string]models2.BidStatusType{
+		"approved":  models2.BidStatusApproved,
+		"published": models2.BidStatusCanceled,
+		"created":   models2.BidStatusCanceled,
+	}
+	for name, bid := range map[string]models2.Bid{"approved": approved, "published": published, "created": created} {
+		current, err := repositories.Bids.GetByID(ctx, bid.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if current.Status != want[name] {
+			t.Errorf("%s bid status = %s, want %s", name, current.Status, want[name])
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUserNotFound             = errors.New("user not found")
+	ErrForbidden                = errors.New("not enough rights")
+	ErrOrganizationNotFound     = errors.New("organization not found")
+	ErrTenderNotFound           = errors.New("tender not found")
+	ErrTenderVersionNotFound    = errors.New("tender version not found")
+	ErrBidNotFound              = errors.New("bid not found")
+	ErrBidVersionNotFound       = errors.New("bid version not found")
+	ErrAuthorBidsNotFound       = errors.New("author has no bids for tender")
+	ErrDecisionNotAllowed       = errors.New("decision cannot be submitted")
+	ErrBidTenderMismatch        = errors.New("organization cannot bid on tender")
+	ErrTenderClosed             = errors.New("tender is closed")
+	ErrSubmissionDeadlinePassed = errors.New("submission deadline has passed")
+	ErrPublicationNotScheduled  = errors.New("tender publication is not scheduled")
+	ErrPublicationNotAllowed    = errors.New("publication can be scheduled only for created tender")
+	ErrVersionConflict          = errors.New("version conflict")
+	ErrInvalidTransition        = errors.New("invalid status transition")
+)
+
+// TransitionError возвращается, когда объект нельзя перевести из текущего статуса в запрошенный.
+// From, To и Allowed - статусы тендера или предложения, в JSON они кодируются в написании из спецификации
+type TransitionError struct {
+	From any
+	To   any
+	// Allowed - статусы, в которые можно перейти из текущего
+	Allowed any
+	// Guard - код проверки, которую не прошел объект. Пустой, если перехода нет в таблице
+	Guard string
+}
+
+func (e *TransitionError) Error() string {
+	if e.Guard != "" {
+		return fmt.Sprintf("%s: %v -> %v (%s)", ErrInvalidTransition, e.From, e.To, e.Guard)
+	}
+	return fmt.Sprintf("%s: %v -> %v", ErrInvalidTransition, e.From, e.To)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// VersionConflictError возвращается, когда версия, на которую рассчитывал клиент, уже устарела
+type VersionConflictError struct {
+	CurrentVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s: current version is %d", ErrVersionConflict, e.CurrentVersion)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+// checkVersion сравнивает ожидаемую версию с текущей, нулевая ожидаемая версия не проверяется
+func checkVersion(expectedVersion, currentVersion int) error {
+	if expectedVersion != 0 && expectedVersion != currentVersion {
+		return &VersionConflictError{CurrentVersion: currentVersion}
+	}
+	return nil
+}
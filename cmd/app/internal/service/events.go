@@ -0,0 +1,25 @@
+package service
+
+import models2 "zadanie-6105/cmd/app/internal/models"
+
+// EventRecorder получает уведомления о бизнес-событиях после успешной фиксации транзакции, например для метрик
+type EventRecorder interface {
+	TenderCreated()
+	TenderPublished()
+	BidSubmitted()
+	BidDecisionMade(decision models2.BidDecisionType)
+}
+
+type noopRecorder struct{}
+
+func (noopRecorder) TenderCreated()                          {}
+func (noopRecorder) TenderPublished()                        {}
+func (noopRecorder) BidSubmitted()                           {}
+func (noopRecorder) BidDecisionMade(models2.BidDecisionType) {}
+
+func recorderOrNoop(events EventRecorder) EventRecorder {
+	if events == nil {
+		return noopRecorder{}
+	}
+	return events
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"testing"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage/memory"
+)
+
+var (
+	testOrganizationID = uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")
+	testResponsible    = models2.Employee{ID: uuid.MustParse("11111111-1111-1111-1111-111111111111"), Username: "user1"}
+	testAuthor         = models2.Employee{ID: uuid.MustParse("22222222-2222-2222-2222-222222222222"), Username: "user2"}
+)
+
+// newTestStore создает хранилище в памяти с организацией, одним ответственным за нее testResponsible
+// и автором предложений testAuthor
+func newTestStore(t *testing.T) *memory.Store {
+	t.Helper()
+
+	store := memory.New()
+	for _, employee := range []models2.Employee{testResponsible, testAuthor} {
+		if err := store.AddEmployee(employee); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.AddOrganization(models2.Organization{ID: testOrganizationID, Name: "ООО Ромашка"})
+	if err := store.AddResponsible(models2.OrganizationResponsible{
+		OrganizationID: testOrganizationID,
+		UserID:         testResponsible.ID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// addResponsibles добавляет организации еще count ответственных и возвращает их вместе с testResponsible
+func addResponsibles(t *testing.T, store *memory.Store, count int) []models2.Employee {
+	t.Helper()
+
+	responsibles := []models2.Employee{testResponsible}
+	for i := range count {
+		employee := models2.Employee{ID: uuid.New(), Username: fmt.Sprintf("responsible%d", i+2)}
+		if err := store.AddEmployee(employee); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.AddResponsible(models2.OrganizationResponsible{
+			OrganizationID: testOrganizationID,
+			UserID:         employee.ID,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		responsibles = append(responsibles, employee)
+	}
+	return responsibles
+}
+
+func createTestTender(t *testing.T, store *memory.Store) models2.Tender {
+	t.Helper()
+
+	tender, err := NewTenderService(store.Repositories(), store, nil).Create(context.Background(), testResponsible, CreateTenderInput{
+		Name:           "Тендер",
+		Description:    "Описание",
+		ServiceType:    models2.ServiceTypeDelivery,
+		OrganizationID: testOrganizationID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tender
+}
+
+func createTestBid(t *testing.T, store *memory.Store, tenderID uuid.UUID) models2.Bid {
+	t.Helper()
+
+	bid, err := NewBidService(store.Repositories(), store, nil).Create(context.Background(), testAuthor, CreateBidInput{
+		Name:           "Предложение",
+		Description:    "Описание",
+		TenderID:       tenderID,
+		OrganizationID: testOrganizationID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bid
+}
+
+// publishTestTender создает и публикует тендер
+func publishTestTender(t *testing.T, store *memory.Store) models2.Tender {
+	t.Helper()
+
+	created := createTestTender(t, store)
+	tender, err := NewTenderService(store.Repositories(), store, nil).
+		UpdateStatus(context.Background(), testResponsible, created.ID, models2.TenderStatusPublished, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tender
+}
+
+// publishTestBid создает и публикует предложение по опубликованному тендеру
+func publishTestBid(t *testing.T, store *memory.Store, tenderID uuid.UUID) models2.Bid {
+	t.Helper()
+
+	created := createTestBid(t, store, tenderID)
+	bid, err := NewBidService(store.Repositories(), store, nil).
+		UpdateStatus(context.Background(), testResponsible, created.ID, models2.BidStatusPublished, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bid
+}
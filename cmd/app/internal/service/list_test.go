@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"github.com/shopspring/decimal"
+	"testing"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+func TestTenderListFiltersVersions(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	created := createTestTender(t, store)
+	tenders := NewTenderService(store.Repositories(), store, nil)
+
+	// v2 - бюджет 100 RUB, v3 - 500 RUB, v4 - публикация, v5 - закрытие
+	for _, amount := range []int64{100, 500} {
+		budget := models2.Money{Amount: decimal.NewFromInt(amount), Currency: "RUB"}
+		if _, err := tenders.Edit(ctx, testResponsible, created.ID, TenderPatch{Budget: budget}, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, status := range []models2.TenderStatusType{models2.TenderStatusPublished, models2.TenderStatusClosed} {
+		if _, err := tenders.UpdateStatus(ctx, testResponsible, created.ID, status, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	budgetMin := decimal.NewFromInt(200)
+	tests := []struct {
+		name   string
+		filter storage.TenderFilter
+		want   []int
+	}{
+		{name: "without filters", filter: storage.TenderFilter{}, want: []int{5, 4, 3, 2, 1}},
+		{name: "status", filter: storage.TenderFilter{Status: models2.TenderStatusClosed}, want: []int{5}},
+		{name: "budget", filter: storage.TenderFilter{BudgetMin: &budgetMin, BudgetCurrency: "RUB"}, want: []int{5, 4, 3}},
+		{name: "service type", filter: storage.TenderFilter{ServiceType: created.ServiceType}, want: []int{5, 4, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Limit = -1
+			list, err := tenders.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]int, 0, len(list))
+			for _, tender := range list {
+				got = append(got, tender.Version)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("versions = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("versions = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
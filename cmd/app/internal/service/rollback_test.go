@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+func TestTenderRollbackKeepsStatus(t *testing.T) {
+	for _, status := range []models2.TenderStatusType{models2.TenderStatusPublished, models2.TenderStatusClosed} {
+		t.Run(string(status), func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			created := createTestTender(t, store)
+			tenders := NewTenderService(store.Repositories(), store, nil)
+
+			if _, err := tenders.Edit(ctx, testResponsible, created.ID, TenderPatch{Name: "Новое название"}, 0); err != nil {
+				t.Fatal(err)
+			}
+			changed, err := tenders.UpdateStatus(ctx, testResponsible, created.ID, status, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tender, err := tenders.Rollback(ctx, testResponsible, created.ID, created.Version, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tender.Name != created.Name || tender.Status != status || tender.Version != changed.Version+1 {
+				t.Fatalf("tender = %q %s v%d, want %q %s v%d",
+					tender.Name, tender.Status, tender.Version, created.Name, status, changed.Version+1)
+			}
+
+			// Откат не меняет статус, поэтому не попадает в историю переходов
+			history, err := store.Repositories().Tenders.ListTransitions(ctx, created.ID, -1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(history) != 1 {
+				t.Fatalf("tender transitions = %d, want 1", len(history))
+			}
+		})
+	}
+}
+
+func TestTenderRollbackPublishAt(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		publishAt time.Time
+		publish   bool
+		want      *time.Time
+	}{
+		{name: "scheduled publication is restored", publishAt: future, want: &future},
+		{name: "past publication is cleared", publishAt: past},
+		{name: "publication of published tender is cleared", publishAt: future, publish: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			created := createTestTender(t, store)
+			tenders := NewTenderService(store.Repositories(), store, nil)
+
+			scheduled, err := tenders.Edit(ctx, testResponsible, created.ID, TenderPatch{PublishAt: &tt.publishAt}, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tenders.CancelScheduledPublication(ctx, testResponsible, created.ID, 0); err != nil {
+				t.Fatal(err)
+			}
+			if tt.publish {
+				if _, err := tenders.UpdateStatus(ctx, testResponsible, created.ID, models2.TenderStatusPublished, 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			tender, err := tenders.Rollback(ctx, testResponsible, created.ID, scheduled.Version, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (tender.PublishAt == nil) != (tt.want == nil) || tender.PublishAt != nil && !tender.PublishAt.Equal(*tt.want) {
+				t.Fatalf("PublishAt = %v, want %v", tender.PublishAt, tt.want)
+			}
+		})
+	}
+}
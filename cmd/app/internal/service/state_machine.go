@@ -0,0 +1,52 @@
+package service
+
+import "slices"
+
+// stateMachine - таблица допустимых переходов между статусами и проверки, которые объект должен пройти,
+// чтобы перейти в статус. S - тип статуса, T - объект, по которому выполняются проверки
+type stateMachine[S ~string, T any] struct {
+	transitions map[S][]S
+	guards      map[S][]transitionGuard[T]
+}
+
+// transitionGuard запрещает переход в статус, если объект не выполняет условие.
+// name - стабильный код причины отказа, он возвращается клиенту. err, если задана, возвращается вместо TransitionError:
+// для отказов, у которых уже есть собственная ошибка
+type transitionGuard[T any] struct {
+	name  string
+	allow func(object T) bool
+	err   error
+}
+
+// Allowed возвращает статусы, в которые можно перейти из from
+func (m stateMachine[S, T]) Allowed(from S) []S {
+	return append([]S{}, m.transitions[from]...)
+}
+
+// Final сообщает, что из статуса status переходов нет
+func (m stateMachine[S, T]) Final(status S) bool {
+	return len(m.transitions[status]) == 0
+}
+
+// Check возвращает TransitionError, если переход from -> to отсутствует в таблице или объект не проходит проверку.
+// Переход в текущий статус всегда разрешен
+func (m stateMachine[S, T]) Check(object T, from, to S) error {
+	if from == to {
+		return nil
+	}
+
+	if !slices.Contains(m.transitions[from], to) {
+		return &TransitionError{From: from, To: to, Allowed: m.Allowed(from)}
+	}
+
+	for _, guard := range m.guards[to] {
+		if !guard.allow(object) {
+			if guard.err != nil {
+				return guard.err
+			}
+			return &TransitionError{From: from, To: to, Allowed: m.Allowed(from), Guard: guard.name}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+// checkTransition сверяет результат Check с ожидаемым: nil, ErrSubmissionDeadlinePassed
+// или TransitionError с проверкой guard (пустой guard - перехода нет в таблице)
+func checkTransition(t *testing.T, err error, allowed bool, guard string, wantErr error) {
+	t.Helper()
+
+	switch {
+	case allowed:
+		if err != nil {
+			t.Fatalf("Check() error = %v, want nil", err)
+		}
+	case wantErr != nil:
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("Check() error = %v, want %v", err, wantErr)
+		}
+	default:
+		var transitionErr *TransitionError
+		if !errors.As(err, &transitionErr) || transitionErr.Guard != guard {
+			t.Fatalf("Check() error = %v, want transition error with guard %q", err, guard)
+		}
+	}
+}
+
+func TestTenderStateMachine(t *testing.T) {
+	described := models2.Tender{Description: "Описание"}
+
+	tests := []struct {
+		name    string
+		tender  models2.Tender
+		from    models2.TenderStatusType
+		to      models2.TenderStatusType
+		allowed bool
+		guard   string
+	}{
+		{name: "created to published", tender: described, from: models2.TenderStatusCreated, to: models2.TenderStatusPublished, allowed: true},
+		{name: "created to closed", tender: described, from: models2.TenderStatusCreated, to: models2.TenderStatusClosed, allowed: true},
+		{name: "published to closed", tender: described, from: models2.TenderStatusPublished, to: models2.TenderStatusClosed, allowed: true},
+		{name: "same status", tender: described, from: models2.TenderStatusClosed, to: models2.TenderStatusClosed, allowed: true},
+		{name: "published to created", tender: described, from: models2.TenderStatusPublished, to: models2.TenderStatusCreated},
+		{name: "closed to published", tender: described, from: models2.TenderStatusClosed, to: models2.TenderStatusPublished},
+		{name: "closed to created", tender: described, from: models2.TenderStatusClosed, to: models2.TenderStatusCreated},
+		{
+			name:  "publish without description",
+			from:  models2.TenderStatusCreated,
+			to:    models2.TenderStatusPublished,
+			guard: GuardDescriptionRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTransition(t, tenderStateMachine.Check(tt.tender, tt.from, tt.to), tt.allowed, tt.guard, nil)
+		})
+	}
+}
+
+func TestBidStateMachine(t *testing.T) {
+	now := time.Now()
+	published := models2.Tender{Status: models2.TenderStatusPublished}
+	expired := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		tender  models2.Tender
+		from    models2.BidStatusType
+		to      models2.BidStatusType
+		allowed bool
+		guard   string
+		err     error
+	}{
+		{name: "created to published", tender: published, from: models2.BidStatusCreated, to: models2.BidStatusPublished, allowed: true},
+		{name: "created to canceled", tender: published, from: models2.BidStatusCreated, to: models2.BidStatusCanceled, allowed: true},
+		{name: "published to canceled", tender: published, from: models2.BidStatusPublished, to: models2.BidStatusCanceled, allowed: true},
+		{name: "published to approved", tender: published, from: models2.BidStatusPublished, to: models2.BidStatusApproved, allowed: true},
+		{name: "published to rejected", tender: published, from: models2.BidStatusPublished, to: models2.BidStatusRejected, allowed: true},
+		{name: "created to approved", tender: published, from: models2.BidStatusCreated, to: models2.BidStatusApproved},
+		{name: "published to created", tender: published, from: models2.BidStatusPublished, to: models2.BidStatusCreated},
+		{name: "canceled to published", tender: published, from: models2.BidStatusCanceled, to: models2.BidStatusPublished},
+		{name: "approved to rejected", tender: published, from: models2.BidStatusApproved, to: models2.BidStatusRejected},
+		{name: "rejected to published", tender: published, from: models2.BidStatusRejected, to: models2.BidStatusPublished},
+		{
+			name:  "publish for created tender",
+			from:  models2.BidStatusCreated,
+			to:    models2.BidStatusPublished,
+			guard: GuardPublishedTenderRequired,
+		},
+		{
+			name:   "publish for closed tender",
+			tender: models2.Tender{Status: models2.TenderStatusClosed},
+			from:   models2.BidStatusCreated,
+			to:     models2.BidStatusPublished,
+			guard:  GuardPublishedTenderRequired,
+		},
+		{
+			name:   "publish after submission deadline",
+			tender: models2.Tender{Status: models2.TenderStatusPublished, SubmissionDeadline: &expired},
+			from:   models2.BidStatusCreated,
+			to:     models2.BidStatusPublished,
+			err:    ErrSubmissionDeadlinePassed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bidStateMachine.Check(bidTransition{tender: tt.tender, now: now}, tt.from, tt.to)
+			checkTransition(t, err, tt.allowed, tt.guard, tt.err)
+		})
+	}
+}
+
+func TestFinalStatuses(t *testing.T) {
+	for _, status := range []models2.TenderStatusType{models2.TenderStatusCreated, models2.TenderStatusPublished} {
+		if tenderStateMachine.Final(status) {
+			t.Errorf("tender status %s is final", status)
+		}
+	}
+	if !tenderStateMachine.Final(models2.TenderStatusClosed) {
+		t.Errorf("tender status %s is not final", models2.TenderStatusClosed)
+	}
+
+	for _, status := range []models2.BidStatusType{models2.BidStatusCreated, models2.BidStatusPublished} {
+		if bidStateMachine.Final(status) {
+			t.Errorf("bid status %s is final", status)
+		}
+	}
+	for _, status := range []models2.BidStatusType{models2.BidStatusCanceled, models2.BidStatusApproved, models2.BidStatusRejected} {
+		if !bidStateMachine.Final(status) || len(bidStateMachine.Allowed(status)) != 0 {
+			t.Errorf("bid status %s is not final", status)
+		}
+	}
+}
+
+func TestFinalBidCannotBeEdited(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	tender := createTestTender(t, store)
+	created := createTestBid(t, store, tender.ID)
+	bids := NewBidService(store.Repositories(), store, nil)
+
+	canceled, err := bids.UpdateStatus(ctx, testResponsible, created.ID, models2.BidStatusCanceled, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := "Новое название"
+	_, err = bids.Edit(ctx, testAuthor, created.ID, BidPatch{Name: &name}, 0)
+	checkTransition(t, err, false, GuardFinalStatus, nil)
+
+	_, err = bids.Rollback(ctx, testResponsible, created.ID, created.Version, 0)
+	checkTransition(t, err, false, GuardFinalStatus, nil)
+
+	assertBidRolledBack(t, store, canceled)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+func TestTenderStatusChangeBumpsVersion(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	created := createTestTender(t, store)
+	tenders := NewTenderService(store.Repositories(), store, nil)
+
+	published, err := tenders.UpdateStatus(ctx, testResponsible, created.ID, models2.TenderStatusPublished, created.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published.Version != created.Version+1 {
+		t.Fatalf("version after status change = %d, want %d", published.Version, created.Version+1)
+	}
+
+	_, err = tenders.UpdateStatus(ctx, testResponsible, created.ID, models2.TenderStatusClosed, created.Version)
+	var conflict *VersionConflictError
+	if !errors.As(err, &conflict) || conflict.CurrentVersion != published.Version {
+		t.Fatalf("UpdateStatus() with stale version error = %v, want conflict at version %d", err, published.Version)
+	}
+}
+
+func TestTenderCloseBumpsCanceledBidVersion(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	tender := createTestTender(t, store)
+	created := createTestBid(t, store, tender.ID)
+	tenders := NewTenderService(store.Repositories(), store, nil)
+
+	if _, err := tenders.UpdateStatus(ctx, testResponsible, tender.ID, models2.TenderStatusClosed, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	bid, err := store.Repositories().Bids.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bid.Status != models2.BidStatusCanceled || bid.Version != created.Version+1 {
+		t.Fatalf("bid = %s v%d, want %s v%d", bid.Status, bid.Version, models2.BidStatusCanceled, created.Version+1)
+	}
+}
+
+func TestBidPublishAfterSubmissionDeadline(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	tenders := NewTenderService(store.Repositories(), store, nil)
+	bids := NewBidService(store.Repositories(), store, nil)
+
+	tender := createTestTender(t, store)
+	if _, err := tenders.UpdateStatus(ctx, testResponsible, tender.ID, models2.TenderStatusPublished, 0); err != nil {
+		t.Fatal(err)
+	}
+	bid := createTestBid(t, store, tender.ID)
+
+	// Срок истек, но планировщик еще не закрыл тендер
+	deadline := time.Now().Add(-time.Minute)
+	if _, err := tenders.Edit(ctx, testResponsible, tender.ID, TenderPatch{SubmissionDeadline: &deadline}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := bids.UpdateStatus(ctx, testResponsible, bid.ID, models2.BidStatusPublished, 0)
+	if !errors.Is(err, ErrSubmissionDeadlinePassed) {
+		t.Fatalf("UpdateStatus() error = %v, want %v", err, ErrSubmissionDeadlinePassed)
+	}
+
+	if _, err := tenders.Edit(ctx, testResponsible, tender.ID, TenderPatch{ClearSubmissionDeadline: true}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bids.UpdateStatus(ctx, testResponsible, bid.ID, models2.BidStatusPublished, 0); err != nil {
+		t.Fatalf("UpdateStatus() after clearing deadline error = %v", err)
+	}
+}
@@ -0,0 +1,636 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+// TenderService - бизнес-правила тендеров. Методы изменения принимают expectedVersion - версию, которую видел клиент.
+// Если тендер успел измениться, возвращается VersionConflictError, нулевое значение отключает проверку
+type TenderService struct {
+	tenders       storage.TenderRepository
+	organizations storage.OrganizationRepository
+	transactor    storage.Transactor
+	events        EventRecorder
+}
+
+// NewTenderService создает сервис тендеров. Изменения тендеров выполняются в транзакциях transactor,
+// чтение - через переданные репозитории. events может быть nil
+func NewTenderService(repositories storage.Repositories, transactor storage.Transactor, events EventRecorder) *TenderService {
+	return &TenderService{
+		tenders:       repositories.Tenders,
+		organizations: repositories.Organizations,
+		transactor:    transactor,
+		events:        recorderOrNoop(events),
+	}
+}
+
+// GuardDescriptionRequired - тендер без описания нельзя опубликовать
+const GuardDescriptionRequired = "DESCRIPTION_REQUIRED"
+
+// SystemActor - автор изменений, которые приложение выполняет само, без запроса пользователя
+const SystemActor = "system"
+
+// tenderStateMachine - допустимые переходы статусов тендера. Закрытый тендер не открывается повторно:
+// по нему уже может быть согласовано предложение
+var tenderStateMachine = stateMachine[models2.TenderStatusType, models2.Tender]{
+	transitions: map[models2.TenderStatusType][]models2.TenderStatusType{
+		models2.TenderStatusCreated:   {models2.TenderStatusPublished, models2.TenderStatusClosed},
+		models2.TenderStatusPublished: {models2.TenderStatusClosed},
+	},
+	guards: map[models2.TenderStatusType][]transitionGuard[models2.Tender]{
+		models2.TenderStatusPublished: {
+			{
+				name: GuardDescriptionRequired,
+				allow: func(tender models2.Tender) bool {
+					return strings.TrimSpace(tender.Description) != ""
+				},
+			},
+		},
+	},
+}
+
+type CreateTenderInput struct {
+	Name               string
+	Description        string
+	ServiceType        string
+	OrganizationID     uuid.UUID
+	SubmissionDeadline *time.Time
+	PublishAt          *time.Time
+	// Budget - бюджет тендера, нулевое значение означает, что бюджет не указан
+	Budget models2.Money
+}
+
+// TenderPatch содержит новые значения полей тендера, пустые значения не меняются
+type TenderPatch struct {
+	Name               string
+	Description        string
+	ServiceType        string
+	SubmissionDeadline *time.Time
+	// ClearSubmissionDeadline снимает срок подачи предложений, SubmissionDeadline при этом не учитывается
+	ClearSubmissionDeadline bool
+	PublishAt               *time.Time
+	Budget                  models2.Money
+}
+
+func (s *TenderService) Create(ctx context.Context, actor models2.Employee, input CreateTenderInput) (models2.Tender, error) {
+	tender := models2.Tender{
+		ID:              uuid.New(),
+		Name:            input.Name,
+		Description:     input.Description,
+		ServiceType:     input.ServiceType,
+		Status:          models2.TenderStatusCreated,
+		OrganizationID:  input.OrganizationID,
+		CreatorUsername: actor.Username,
+		Version:         1,
+
+		SubmissionDeadline: input.SubmissionDeadline,
+		PublishAt:          input.PublishAt,
+		Budget:             input.Budget,
+	}
+
+	err := s.transactor.WithinTransaction(ctx, func(repositories storage.Repositories) error {
+		if err := requireResponsible(ctx, repositories.Organizations, actor.ID, input.OrganizationID); err != nil {
+			return err
+		}
+
+		if err := repositories.Tenders.Create(ctx, &tender); err != nil {
+			return fmt.Errorf("create tender: %w", err)
+		}
+
+		if err := repositories.Tenders.CreateVersion(ctx, newTenderVersion(tender, actor.Username)); err != nil {
+			return fmt.Errorf("create tender version: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models2.Tender{}, err
+	}
+
+	s.events.TenderCreated()
+	return tender, nil
+}
+
+// List возвращает версии тендеров, подходящих под фильтр. Фильтры по содержимому тендера применяются и к версиям:
+// в ответ не попадают версии, которые сами под фильтр не подходят
+func (s *TenderService) List(ctx context.Context, filter storage.TenderFilter) ([]models2.TenderResponse, error) {
+	tenders, err := s.tenders.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list tenders: %w", err)
+	}
+
+	response := make([]models2.TenderResponse, 0, len(tenders))
+	for _, tender := range tenders {
+		versions, err := s.tenders.ListVersions(ctx, tender.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list tender versions: %w", err)
+		}
+
+		for _, version := range versions {
+			if !versionMatches(version, filter) {
+				continue
+			}
+			response = append(response, models2.TenderResponse{
+				ID:             tender.ID,
+				Name:           version.Name,
+				Description:    version.Description,
+				ServiceType:    version.ServiceType,
+				Status:         version.Status,
+				OrganizationID: tender.OrganizationID,
+				CreatedAt:      version.CreatedAt,
+				Version:        version.Version,
+
+				SubmissionDeadline: version.SubmissionDeadline,
+				PublishAt:          version.PublishAt,
+				Budget:             version.Budget.Ptr(),
+			})
+		}
+	}
+
+	return response, nil
+}
+
+// versionMatches проверяет версию тендера по фильтрам, значения которых меняются от версии к версии
+func versionMatches(version models2.TenderVersion, filter storage.TenderFilter) bool {
+	if filter.ServiceType != "" && version.ServiceType != filter.ServiceType {
+		return false
+	}
+	if filter.Status != "" && version.Status != filter.Status {
+		return false
+	}
+	return filter.BudgetMatches(version.Budget)
+}
+
+func (s *TenderService) ListByUser(ctx context.Context, actor models2.Employee, limit, offset int) ([]models2.TenderResponse, error) {
+	return s.List(ctx, storage.TenderFilter{
+		CreatorUsername: actor.Username,
+		Limit:           limit,
+		Offset:          offset,
+	})
+}
+
+func (s *TenderService) GetStatus(ctx context.Context, actor models2.Employee, tenderID uuid.UUID) (models2.TenderStatusType, error) {
+	tender, err := getTender(ctx, s.tenders, tenderID)
+	if err != nil {
+		return "", err
+	}
+
+	// Опубликованный тендер доступен всем, остальные - только ответственным за организацию
+	switch tender.Status {
+	case models2.TenderStatusPublished:
+	case models2.TenderStatusCreated, models2.TenderStatusClosed:
+		if err := requireResponsible(ctx, s.organizations, actor.ID, tender.OrganizationID); err != nil {
+			return "", err
+		}
+	default:
+		return "", ErrForbidden
+	}
+
+	return tender.Status, nil
+}
+
+func (s *TenderService) UpdateStatus(
+	ctx context.Context,
+	actor models2.Employee,
+	tenderID uuid.UUID,
+	status models2.TenderStatusType,
+	expectedVersion int,
+) (models2.Tender, error) {
+	isPublished := false
+	tender, err := s.update(ctx, actor, tenderID, expectedVersion, func(repositories storage.Repositories, tender *models2.Tender) error {
+		isPublished = tender.Status != models2.TenderStatusPublished && status == models2.TenderStatusPublished
+		return transitionTender(ctx, repositories, tender, status, actor.Username)
+	})
+	if err != nil {
+		return models2.Tender{}, err
+	}
+
+	if isPublished {
+		s.events.TenderPublished()
+	}
+	return tender, nil
+}
+
+func (s *TenderService) Edit(
+	ctx context.Context,
+	actor models2.Employee,
+	tenderID uuid.UUID,
+	patch TenderPatch,
+	expectedVersion int,
+) (models2.Tender, error) {
+	return s.update(ctx, actor, tenderID, expectedVersion, func(repositories storage.Repositories, tender *models2.Tender) error {
+		isUpdated := false
+		if patch.Name != "" && patch.Name != tender.Name {
+			tender.Name = patch.Name
+			isUpdated = true
+		}
+		if patch.Description != "" && patch.Description != tender.Description {
+			tender.Description = patch.Description
+			isUpdated = true
+		}
+		if patch.ServiceType != "" && patch.ServiceType != tender.ServiceType {
+			tender.ServiceType = patch.ServiceType
+			isUpdated = true
+		}
+		switch {
+		case patch.ClearSubmissionDeadline:
+			if tender.SubmissionDeadline != nil {
+				tender.SubmissionDeadline = nil
+				isUpdated = true
+			}
+		case patch.SubmissionDeadline != nil &&
+			(tender.SubmissionDeadline == nil || !patch.SubmissionDeadline.Equal(*tender.SubmissionDeadline)):
+			tender.SubmissionDeadline = patch.SubmissionDeadline
+			isUpdated = true
+		}
+		if patch.PublishAt != nil && (tender.PublishAt == nil || !patch.PublishAt.Equal(*tender.PublishAt)) {
+			// Планировать публикацию имеет смысл только для тендера, который еще не публиковался
+			if tender.Status != models2.TenderStatusCreated {
+				return ErrPublicationNotAllowed
+			}
+			tender.PublishAt = patch.PublishAt
+			isUpdated = true
+		}
+		if !patch.Budget.IsZero() && !patch.Budget.Equal(tender.Budget) {
+			tender.Budget = patch.Budget
+			isUpdated = true
+		}
+
+		if !isUpdated {
+			return nil
+		}
+
+		return appendTenderVersion(ctx, repositories.Tenders, tender, actor.Username)
+	})
+}
+
+// Rollback восстанавливает параметры тендера из версии и сохраняет результат как новую версию.
+// Статус не откатывается: он меняется только через UpdateStatus по таблице переходов
+func (s *TenderService) Rollback(
+	ctx context.Context,
+	actor models2.Employee,
+	tenderID uuid.UUID,
+	version int,
+	expectedVersion int,
+) (models2.Tender, error) {
+	return s.update(ctx, actor, tenderID, expectedVersion, func(repositories storage.Repositories, tender *models2.Tender) error {
+		tenderVersion, err := repositories.Tenders.GetVersion(ctx, tender.ID, version)
+		if err != nil {
+			return notFound(err, ErrTenderVersionNotFound, "get tender version")
+		}
+
+		tender.Name = tenderVersion.Name
+		tender.Description = tenderVersion.Description
+		tender.ServiceType = tenderVersion.ServiceType
+		tender.SubmissionDeadline = tenderVersion.SubmissionDeadline
+		tender.Budget = tenderVersion.Budget
+
+		// Отложенная публикация из старой версии имеет смысл, только пока тендер не публиковался и время не прошло
+		tender.PublishAt = nil
+		if tender.Status == models2.TenderStatusCreated &&
+			tenderVersion.PublishAt != nil && tenderVersion.PublishAt.After(time.Now()) {
+			tender.PublishAt = tenderVersion.PublishAt
+		}
+
+		return appendTenderVersion(ctx, repositories.Tenders, tender, actor.Username)
+	})
+}
+
+// update блокирует тендер, проверяет ответственность пользователя и ожидаемую версию и применяет apply в одной транзакции
+func (s *TenderService) update(
+	ctx context.Context,
+	actor models2.Employee,
+	tenderID uuid.UUID,
+	expectedVersion int,
+	apply func(repositories storage.Repositories, tender *models2.Tender) error,
+) (models2.Tender, error) {
+	var tender models2.Tender
+	err := s.transactor.WithinTransaction(ctx, func(repositories storage.Repositories) error {
+		var err error
+		tender, err = repositories.Tenders.GetByIDForUpdate(ctx, tenderID)
+		if err != nil {
+			return notFound(err, ErrTenderNotFound, "get tender")
+		}
+
+		if err := requireResponsible(ctx, repositories.Organizations, actor.ID, tender.OrganizationID); err != nil {
+			return err
+		}
+
+		if err := checkVersion(expectedVersion, tender.Version); err != nil {
+			return err
+		}
+
+		return apply(repositories, &tender)
+	})
+	if err != nil {
+		return models2.Tender{}, err
+	}
+
+	return tender, nil
+}
+
+func getTender(ctx context.Context, tenders storage.TenderRepository, tenderID uuid.UUID) (models2.Tender, error) {
+	tender, err := tenders.GetByID(ctx, tenderID)
+	if err != nil {
+		return models2.Tender{}, notFound(err, ErrTenderNotFound, "get tender")
+	}
+	return tender, nil
+}
+
+// ListTransitions возвращает историю смены статусов тендера, начиная с последних. Историю видят только ответственные
+func (s *TenderService) ListTransitions(
+	ctx context.Context,
+	actor models2.Employee,
+	tenderID uuid.UUID,
+	limit int,
+	offset int,
+) ([]models2.TenderTransition, error) {
+	tender, err := getTender(ctx, s.tenders, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireResponsible(ctx, s.organizations, actor.ID, tender.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	transitions, err := s.tenders.ListTransitions(ctx, tenderID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list tender transitions: %w", err)
+	}
+	return transitions, nil
+}
+
+// CloseExpired закрывает опубликованные тендеры, срок подачи предложений по которым наступил к now.
+// Каждый тендер закрывается в своей транзакции, ошибка по одному тендеру не мешает закрыть остальные.
+// Возвращает число закрытых тендеров
+func (s *TenderService) CloseExpired(ctx context.Context, now time.Time) (int, error) {
+	tenders, err := s.tenders.List(ctx, storage.TenderFilter{
+		Status:         models2.TenderStatusPublished,
+		DeadlineBefore: now,
+		Limit:          -1,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list expired tenders: %w", err)
+	}
+
+	closed := 0
+	var errs []error
+	for _, expired := range tenders {
+		isClosed := false
+		err := s.transactor.WithinTransaction(ctx, func(repositories storage.Repositories) error {
+			tender, err := repositories.Tenders.GetByIDForUpdate(ctx, expired.ID)
+			if err != nil {
+				return notFound(err, ErrTenderNotFound, "get tender")
+			}
+
+			// После выборки тендер могли закрыть вручную или продлить срок
+			if tender.Status != models2.TenderStatusPublished || !deadlinePassed(tender, now) {
+				return nil
+			}
+
+			isClosed = true
+			return transitionTender(ctx, repositories, &tender, models2.TenderStatusClosed, SystemActor)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("close tender %s: %w", expired.ID, err))
+			continue
+		}
+		if isClosed {
+			closed++
+		}
+	}
+
+	return closed, errors.Join(errs...)
+}
+
+// PublishScheduled публикует созданные тендеры, время публикации которых наступило к now. Публикация сохраняется
+// новой версией от имени SystemActor. Если тендер не проходит проверки перехода, например у него нет описания,
+// публикация отменяется, чтобы не повторять ее на каждом запуске. Возвращает число опубликованных тендеров
+func (s *TenderService) PublishScheduled(ctx context.Context, now time.Time) (int, error) {
+	tenders, err := s.tenders.List(ctx, storage.TenderFilter{
+		Status:        models2.TenderStatusCreated,
+		PublishBefore: now,
+		Limit:         -1,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list scheduled tenders: %w", err)
+	}
+
+	published := 0
+	var errs []error
+	for _, scheduled := range tenders {
+		isPublished := false
+		var rejected error
+		err := s.transactor.WithinTransaction(ctx, func(repositories storage.Repositories) error {
+			tender, err := repositories.Tenders.GetByIDForUpdate(ctx, scheduled.ID)
+			if err != nil {
+				return notFound(err, ErrTenderNotFound, "get tender")
+			}
+
+			// После выборки тендер могли опубликовать вручную или перенести публикацию
+			if tender.Status != models2.TenderStatusCreated || tender.PublishAt == nil || tender.PublishAt.After(now) {
+				return nil
+			}
+
+			from := tender.Status
+			if rejected = tenderStateMachine.Check(tender, from, models2.TenderStatusPublished); rejected != nil {
+				tender.PublishAt = nil
+				return appendTenderVersion(ctx, repositories.Tenders, &tender, SystemActor)
+			}
+
+			tender.Status = models2.TenderStatusPublished
+			if err := appendTenderVersion(ctx, repositories.Tenders, &tender, SystemActor); err != nil {
+				return err
+			}
+
+			isPublished = true
+			return completeTenderTransition(ctx, repositories, tender, from, SystemActor)
+		})
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("publish tender %s: %w", scheduled.ID, err))
+		case rejected != nil:
+			errs = append(errs, fmt.Errorf("scheduled publication of tender %s canceled: %w", scheduled.ID, rejected))
+		case isPublished:
+			published++
+			s.events.TenderPublished()
+		}
+	}
+
+	return published, errors.Join(errs...)
+}
+
+// ListScheduled возвращает тендеры организации, ожидающие запланированной публикации. Доступно только ответственным
+func (s *TenderService) ListScheduled(
+	ctx context.Context,
+	actor models2.Employee,
+	organizationID uuid.UUID,
+	limit int,
+	offset int,
+) ([]models2.Tender, error) {
+	if err := requireResponsible(ctx, s.organizations, actor.ID, organizationID); err != nil {
+		return nil, err
+	}
+
+	tenders, err := s.tenders.List(ctx, storage.TenderFilter{
+		Status:           models2.TenderStatusCreated,
+		OrganizationID:   organizationID,
+		PublishScheduled: true,
+		Limit:            limit,
+		Offset:           offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list scheduled tenders: %w", err)
+	}
+	return tenders, nil
+}
+
+// CancelScheduledPublication отменяет запланированную публикацию тендера и сохраняет результат как новую версию
+func (s *TenderService) CancelScheduledPublication(
+	ctx context.Context,
+	actor models2.Employee,
+	tenderID uuid.UUID,
+	expectedVersion int,
+) (models2.Tender, error) {
+	return s.update(ctx, actor, tenderID, expectedVersion, func(repositories storage.Repositories, tender *models2.Tender) error {
+		if tender.Status != models2.TenderStatusCreated || tender.PublishAt == nil {
+			return ErrPublicationNotScheduled
+		}
+
+		tender.PublishAt = nil
+		return appendTenderVersion(ctx, repositories.Tenders, tender, actor.Username)
+	})
+}
+
+// deadlinePassed сообщает, наступил ли к now срок подачи предложений по тендеру
+func deadlinePassed(tender models2.Tender, now time.Time) bool {
+	return tender.SubmissionDeadline != nil && !tender.SubmissionDeadline.After(now)
+}
+
+// transitionTender переводит тендер в статус to по таблице переходов и записывает переход в историю от имени actor.
+// Переход в текущий статус ничего не меняет
+func transitionTender(
+	ctx context.Context,
+	repositories storage.Repositories,
+	tender *models2.Tender,
+	to models2.TenderStatusType,
+	actor string,
+) error {
+	from := tender.Status
+	if from == to {
+		return nil
+	}
+
+	if err := tenderStateMachine.Check(*tender, from, to); err != nil {
+		return err
+	}
+
+	if err := setTenderStatus(ctx, repositories.Tenders, tender, to, actor); err != nil {
+		return err
+	}
+
+	return completeTenderTransition(ctx, repositories, *tender, from, actor)
+}
+
+// completeTenderTransition записывает переход тендера из from в текущий статус в историю.
+// При закрытии тендера открытые предложения по нему отменяются: решение по ним уже не может быть принято
+func completeTenderTransition(
+	ctx context.Context,
+	repositories storage.Repositories,
+	tender models2.Tender,
+	from models2.TenderStatusType,
+	actor string,
+) error {
+	if err := recordTenderTransition(ctx, repositories.Tenders, tender.ID, from, tender.Status, actor); err != nil {
+		return err
+	}
+
+	if tender.Status != models2.TenderStatusClosed {
+		return nil
+	}
+	return cancelOpenBids(ctx, repositories.Bids, tender)
+}
+
+func recordTenderTransition(
+	ctx context.Context,
+	tenders storage.TenderRepository,
+	tenderID uuid.UUID,
+	from models2.TenderStatusType,
+	to models2.TenderStatusType,
+	actor string,
+) error {
+	transition := &models2.TenderTransition{
+		ID:            uuid.New(),
+		TenderID:      tenderID,
+		FromStatus:    from,
+		ToStatus:      to,
+		ActorUsername: actor,
+		CreatedAt:     time.Now(),
+	}
+	if err := tenders.CreateTransition(ctx, transition); err != nil {
+		return fmt.Errorf("create tender transition: %w", err)
+	}
+	return nil
+}
+
+// setTenderStatus меняет статус тендера и сохраняет результат как новую версию от имени author,
+// чтобы смена статуса меняла ETag и конкурентные запросы с устаревшей версией получали конфликт
+func setTenderStatus(
+	ctx context.Context,
+	tenders storage.TenderRepository,
+	tender *models2.Tender,
+	status models2.TenderStatusType,
+	author string,
+) error {
+	tender.Status = status
+	return appendTenderVersion(ctx, tenders, tender, author)
+}
+
+// appendTenderVersion сохраняет текущее состояние тендера как следующую версию от имени author
+func appendTenderVersion(ctx context.Context, tenders storage.TenderRepository, tender *models2.Tender, author string) error {
+	nextVersion := tender.Version + 1
+	latestVersion, err := tenders.GetLatestVersion(ctx, tender.ID)
+	switch {
+	case err == nil:
+		nextVersion = latestVersion.Version + 1
+	case !errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("get latest tender version: %w", err)
+	}
+
+	tender.Version = nextVersion
+	if err := tenders.CreateVersion(ctx, newTenderVersion(*tender, author)); err != nil {
+		return fmt.Errorf("create tender version: %w", err)
+	}
+
+	if err := tenders.Update(ctx, tender); err != nil {
+		return fmt.Errorf("update tender: %w", err)
+	}
+
+	return nil
+}
+
+func newTenderVersion(tender models2.Tender, author string) *models2.TenderVersion {
+	return &models2.TenderVersion{
+		ID:          uuid.New(),
+		TenderID:    tender.ID,
+		Version:     tender.Version,
+		Name:        tender.Name,
+		Description: tender.Description,
+		ServiceType: tender.ServiceType,
+		Status:      tender.Status,
+		CreatedAt:   time.Now(),
+
+		SubmissionDeadline: tender.SubmissionDeadline,
+		PublishAt:          tender.PublishAt,
+		AuthorUsername:     author,
+		Budget:             tender.Budget,
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+	"zadanie-6105/cmd/app/internal/storage/memory"
+)
+
+var errInjected = errors.New("injected failure")
+
+// failingTransactor выполняет транзакции хранилища в памяти, подменяя в них запись failOn на ошибку.
+// failOn - репозиторий и метод, например "Tenders.Update"
+type failingTransactor struct {
+	store  *memory.Store
+	failOn string
+}
+
+func (t *failingTransactor) WithinTransaction(ctx context.Context, fn func(repositories storage.Repositories) error) error {
+	return t.store.WithinTransaction(ctx, func(repositories storage.Repositories) error {
+		repositories.Tenders = &failingTenders{TenderRepository: repositories.Tenders, failOn: t.failOn}
+		repositories.Bids = &failingBids{BidRepository: repositories.Bids, failOn: t.failOn}
+		return fn(repositories)
+	})
+}
+
+type failingTenders struct {
+	storage.TenderRepository
+	failOn string
+}
+
+func (r *failingTenders) Update(ctx context.Context, tender *models2.Tender) error {
+	if r.failOn == "Tenders.Update" {
+		return errInjected
+	}
+	return r.TenderRepository.Update(ctx, tender)
+}
+
+func (r *failingTenders) CreateVersion(ctx context.Context, version *models2.TenderVersion) error {
+	if r.failOn == "Tenders.CreateVersion" {
+		return errInjected
+	}
+	return r.TenderRepository.CreateVersion(ctx, version)
+}
+
+func (r *failingTenders) CreateTransition(ctx context.Context, transition *models2.TenderTransition) error {
+	if r.failOn == "Tenders.CreateTransition" {
+		return errInjected
+	}
+	return r.TenderRepository.CreateTransition(ctx, transition)
+}
+
+type failingBids struct {
+	storage.BidRepository
+	failOn string
+}
+
+func (r *failingBids) Update(ctx context.Context, bid *models2.Bid) error {
+	if r.failOn == "Bids.Update" {
+		return errInjected
+	}
+	return r.BidRepository.Update(ctx, bid)
+}
+
+func (r *failingBids) CreateVersion(ctx context.Context, version *models2.BidVersion) error {
+	if r.failOn == "Bids.CreateVersion" {
+		return errInjected
+	}
+	return r.BidRepository.CreateVersion(ctx, version)
+}
+
+// assertTenderRolledBack проверяет, что тендер и его последняя версия совпадают с want,
+// а в истории переходов ровно transitions записей
+func assertTenderRolledBack(t *testing.T, store *memory.Store, want models2.Tender, transitions int) {
+	t.Helper()
+
+	ctx := context.Background()
+	repositories := store.Repositories()
+	tender, err := repositories.Tenders.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tender.Name != want.Name || tender.Status != want.Status || tender.Version != want.Version {
+		t.Fatalf("tender = %q %s v%d, want %q %s v%d",
+			tender.Name, tender.Status, tender.Version, want.Name, want.Status, want.Version)
+	}
+
+	latest, err := repositories.Tenders.GetLatestVersion(ctx, want.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Version != want.Version {
+		t.Fatalf("latest tender version = %d, want %d", latest.Version, want.Version)
+	}
+
+	history, err := repositories.Tenders.ListTransitions(ctx, want.ID, -1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != transitions {
+		t.Fatalf("tender transitions = %d, want %d", len(history), transitions)
+	}
+}
+
+// assertBidRolledBack проверяет, что предложение и его последняя версия совпадают с want
+func assertBidRolledBack(t *testing.T, store *memory.Store, want models2.Bid) {
+	t.Helper()
+
+	ctx := context.Background()
+	repositories := store.Repositories()
+	bid, err := repositories.Bids.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bid.Name != want.Name || bid.Status != want.Status || bid.Version != want.Version {
+		t.Fatalf("bid = %q %s v%d, want %q %s v%d",
+			bid.Name, bid.Status, bid.Version, want.Name, want.Status, want.Version)
+	}
+
+	latest, err := repositories.Bids.GetLatestVersion(ctx, want.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Version != want.Version {
+		t.Fatalf("latest bid version = %d, want %d", latest.Version, want.Version)
+	}
+}
+
+func TestTenderCreateRollsBackOnVersionFailure(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	tenders := NewTenderService(store.Repositories(), &failingTransactor{store: store, failOn: "Tenders.CreateVersion"}, nil)
+
+	tender, err := tenders.Create(ctx, testResponsible, CreateTenderInput{
+		Name:           "Тендер",
+		Description:    "Описание",
+		ServiceType:    models2.ServiceTypeDelivery,
+		OrganizationID: testOrganizationID,
+	})
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("Create() error = %v, want %v", err, errInjected)
+	}
+
+	list, err := store.Repositories().Tenders.List(ctx, storage.TenderFilter{Limit: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 0 {
+		t.Fatalf("tender %s is saved without its version", tender.ID)
+	}
+}
+
+func TestTenderEditRollsBackOnUpdateFailure(t *testing.T) {
+	store := newTestStore(t)
+	created := createTestTender(t, store)
+	tenders := NewTenderService(store.Repositories(), &failingTransactor{store: store, failOn: "Tenders.Update"}, nil)
+
+	_, err := tenders.Edit(context.Background(), testResponsible, created.ID, TenderPatch{Name: "Новое название"}, 0)
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("Edit() error = %v, want %v", err, errInjected)
+	}
+
+	assertTenderRolledBack(t, store, created, 0)
+}
+
+func TestTenderRollbackRollsBackOnUpdateFailure(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	created := createTestTender(t, store)
+	edited, err := NewTenderService(store.Repositories(), store, nil).
+		Edit(ctx, testResponsible, created.ID, TenderPatch{Name: "Новое название"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenders := NewTenderService(store.Repositories(), &failingTransactor{store: store, failOn: "Tenders.Update"}, nil)
+
+	_, err = tenders.Rollback(ctx, testResponsible, created.ID, created.Version, 0)
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("Rollback() error = %v, want %v", err, errInjected)
+	}
+
+	assertTenderRolledBack(t, store, edited, 0)
+}
+
+func TestTenderUpdateStatusRollsBack(t *testing.T) {
+	// Закрытие тендера пишет его версию, переход и отмену открытых предложений в одной транзакции
+	for _, failOn := range []string{"Tenders.Update", "Tenders.CreateTransition", "Bids.CreateVersion", "Bids.Update"} {
+		t.Run(failOn, func(t *testing.T) {
+			store := newTestStore(t)
+			tender := createTestTender(t, store)
+			bid := createTestBid(t, store, tender.ID)
+			tenders := NewTenderService(store.Repositories(), &failingTransactor{store: store, failOn: failOn}, nil)
+
+			_, err := tenders.UpdateStatus(context.Background(), testResponsible, tender.ID, models2.TenderStatusClosed, 0)
+			if !errors.Is(err, errInjected) {
+				t.Fatalf("UpdateStatus() error = %v, want %v", err, errInjected)
+			}
+
+			assertTenderRolledBack(t, store, tender, 0)
+			assertBidRolledBack(t, store, bid)
+		})
+	}
+}
+
+func TestBidCreateRollsBackOnVersionFailure(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	tender := createTestTender(t, store)
+	bids := NewBidService(store.Repositories(), &failingTransactor{store: store, failOn: "Bids.CreateVersion"}, nil)
+
+	bid, err := bids.Create(ctx, testAuthor, CreateBidInput{
+		Name:           "Предложение",
+		Description:    "Описание",
+		TenderID:       tender.ID,
+		OrganizationID: testOrganizationID,
+	})
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("Create() error = %v, want %v", err, errInjected)
+	}
+
+	if _, err := store.Repositories().Bids.GetByID(ctx, bid.ID); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("GetByID() error = %v, want %v", err, storage.ErrNotFound)
+	}
+	count, err := store.Repositories().Bids.Count(ctx, storage.BidFilter{TenderID: tender.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("bids for tender = %d, want 0", count)
+	}
+}
+
+func TestBidEditRollsBackOnUpdateFailure(t *testing.T) {
+	store := newTestStore(t)
+	created := createTestBid(t, store, createTestTender(t, store).ID)
+	bids := NewBidService(store.Repositories(), &failingTransactor{store: store, failOn: "Bids.Update"}, nil)
+
+	name := "Новое название"
+	_, err := bids.Edit(context.Background(), testAuthor, created.ID, BidPatch{Name: &name}, 0)
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("Edit() error = %v, want %v", err, errInjected)
+	}
+
+	assertBidRolledBack(t, store, created)
+}
+
+func TestBidRollbackRollsBackOnUpdateFailure(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	created := createTestBid(t, store, createTestTender(t, store).ID)
+	name := "Новое название"
+	edited, err := NewBidService(store.Repositories(), store, nil).Edit(ctx, testAuthor, created.ID, BidPatch{Name: &name}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bids := NewBidService(store.Repositories(), &failingTransactor{store: store, failOn: "Bids.Update"}, nil)
+
+	_, err = bids.Rollback(ctx, testAuthor, created.ID, created.Version, 0)
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("Rollback() error = %v, want %v", err, errInjected)
+	}
+
+	assertBidRolledBack(t, store, edited)
+}
+
+func TestBidUpdateStatusRollsBackOnUpdateFailure(t *testing.T) {
+	store := newTestStore(t)
+	created := createTestBid(t, store, publishTestTender(t, store).ID)
+	bids := NewBidService(store.Repositories(), &failingTransactor{store: store, failOn: "Bids.Update"}, nil)
+
+	_, err := bids.UpdateStatus(context.Background(), testResponsible, created.ID, models2.BidStatusPublished, 0)
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("UpdateStatus() error = %v, want %v", err, errInjected)
+	}
+
+	assertBidRolledBack(t, store, created)
+}
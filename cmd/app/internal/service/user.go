@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+type UserService struct {
+	employees storage.EmployeeRepository
+}
+
+func NewUserService(employees storage.EmployeeRepository) *UserService {
+	return &UserService{employees: employees}
+}
+
+func (s *UserService) GetByUsername(ctx context.Context, username string) (models2.Employee, error) {
+	user, err := s.employees.GetByUsername(ctx, username)
+	if errors.Is(err, storage.ErrNotFound) {
+		return models2.Employee{}, ErrUserNotFound
+	}
+	if err != nil {
+		return models2.Employee{}, fmt.Errorf("get employee: %w", err)
+	}
+	return user, nil
+}
+
+// requireResponsible проверяет, что пользователь ответственен за организацию
+func requireResponsible(ctx context.Context, organizations storage.OrganizationRepository, userID, organizationID uuid.UUID) error {
+	ok, err := organizations.IsResponsible(ctx, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("check organization responsibility: %w", err)
+	}
+	if !ok {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// notFound подменяет storage.ErrNotFound доменной ошибкой
+func notFound(err error, target error, action string) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return target
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
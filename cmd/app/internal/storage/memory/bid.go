@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sort"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+type BidRepository struct {
+	store *Store
+	tx    bool
+}
+
+func (r *BidRepository) Create(_ context.Context, bid *models2.Bid) error {
+	defer r.store.lock(r.tx)()
+
+	if bid.ID == uuid.Nil {
+		bid.ID = uuid.New()
+	}
+	touch(&bid.CreatedAt, &bid.UpdatedAt)
+	r.store.bids[bid.ID] = *bid
+	return nil
+}
+
+func (r *BidRepository) GetByID(_ context.Context, id uuid.UUID) (models2.Bid, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	bid, ok := r.store.bids[id]
+	if !ok {
+		return models2.Bid{}, storage.ErrNotFound
+	}
+	return bid, nil
+}
+
+// filtered возвращает предложения под фильтр, отсортированные по названию. Вызывается под блокировкой
+func (r *BidRepository) filtered(filter storage.BidFilter) []models2.Bid {
+	bids := make([]models2.Bid, 0)
+	for _, bid := range r.store.bids {
+		if filter.TenderID != uuid.Nil && bid.TenderID != filter.TenderID {
+			continue
+		}
+		if filter.CreatorUsername != "" && bid.CreatorUsername != filter.CreatorUsername {
+			continue
+		}
+		if filter.Status != "" && bid.Status != filter.Status {
+			continue
+		}
+		bids = append(bids, bid)
+	}
+
+	sort.Slice(bids, func(i, j int) bool {
+		return bids[i].Name < bids[j].Name
+	})
+	return bids
+}
+
+// GetByIDForUpdate не блокирует запись, см. TenderRepository.GetByIDForUpdate
+func (r *BidRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (models2.Bid, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *BidRepository) List(_ context.Context, filter storage.BidFilter) ([]models2.Bid, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return paginate(r.filtered(filter), filter.Limit, filter.Offset), nil
+}
+
+func (r *BidRepository) Count(_ context.Context, filter storage.BidFilter) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return int64(len(r.filtered(filter))), nil
+}
+
+func (r *BidRepository) Update(_ context.Context, bid *models2.Bid) error {
+	defer r.store.lock(r.tx)()
+
+	if _, ok := r.store.bids[bid.ID]; !ok {
+		return storage.ErrNotFound
+	}
+	touch(nil, &bid.UpdatedAt)
+	r.store.bids[bid.ID] = *bid
+	return nil
+}
+
+func (r *BidRepository) CreateVersion(_ context.Context, version *models2.BidVersion) error {
+	defer r.store.lock(r.tx)()
+
+	if version.ID == uuid.Nil {
+		version.ID = uuid.New()
+	}
+	touch(&version.CreatedAt, nil)
+	r.store.bidVersions[version.BidID] = append(r.store.bidVersions[version.BidID], *version)
+	return nil
+}
+
+func (r *BidRepository) GetVersion(_ context.Context, bidID uuid.UUID, version int) (models2.BidVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, bidVersion := range r.store.bidVersions[bidID] {
+		if bidVersion.Version == version {
+			return bidVersion, nil
+		}
+	}
+	return models2.BidVersion{}, storage.ErrNotFound
+}
+
+func (r *BidRepository) GetLatestVersion(_ context.Context, bidID uuid.UUID) (models2.BidVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	versions := r.store.bidVersions[bidID]
+	if len(versions) == 0 {
+		return models2.BidVersion{}, storage.ErrNotFound
+	}
+
+	latest := versions[0]
+	for _, version := range versions[1:] {
+		if version.Version > latest.Version {
+			latest = version
+		}
+	}
+	return latest, nil
+}
+
+func (r *BidRepository) GetDecision(_ context.Context, bidID, userID uuid.UUID) (models2.BidDecision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, decision := range r.store.decisions {
+		if decision.BidID == bidID && decision.UserID == userID {
+			return decision, nil
+		}
+	}
+	return models2.BidDecision{}, storage.ErrNotFound
+}
+
+func (r *BidRepository) SaveDecision(_ context.Context, decision *models2.BidDecision) error {
+	defer r.store.lock(r.tx)()
+
+	if decision.ID == uuid.Nil {
+		decision.ID = uuid.New()
+	}
+	touch(&decision.CreatedAt, &decision.UpdatedAt)
+	r.store.decisions[decision.ID] = *decision
+	return nil
+}
+
+func (r *BidRepository) CountDecisions(_ context.Context, bidID uuid.UUID, decision models2.BidDecisionType) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, bidDecision := range r.store.decisions {
+		if bidDecision.BidID == bidID && bidDecision.Decision == decision {
+			count++
+		}
+	}
+	return count, nil
+}
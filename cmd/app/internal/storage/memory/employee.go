@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+type EmployeeRepository struct {
+	store *Store
+}
+
+func (r *EmployeeRepository) GetByUsername(_ context.Context, username string) (models2.Employee, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, employee := range r.store.employees {
+		if employee.Username == username {
+			return employee, nil
+		}
+	}
+	return models2.Employee{}, storage.ErrNotFound
+}
+
+type OrganizationRepository struct {
+	store *Store
+}
+
+func (r *OrganizationRepository) GetByID(_ context.Context, id uuid.UUID) (models2.Organization, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	organization, ok := r.store.organizations[id]
+	if !ok {
+		return models2.Organization{}, storage.ErrNotFound
+	}
+	return organization, nil
+}
+
+func (r *OrganizationRepository) IsResponsible(_ context.Context, organizationID, userID uuid.UUID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, responsible := range r.store.responsibles {
+		if responsible.OrganizationID == organizationID && responsible.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *OrganizationRepository) CountResponsibles(_ context.Context, organizationID uuid.UUID) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, responsible := range r.store.responsibles {
+		if responsible.OrganizationID == organizationID {
+			count++
+		}
+	}
+	return count, nil
+}
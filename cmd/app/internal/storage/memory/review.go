@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sort"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+type ReviewRepository struct {
+	store *Store
+	tx    bool
+}
+
+func (r *ReviewRepository) Create(_ context.Context, review *models2.Review) error {
+	defer r.store.lock(r.tx)()
+
+	if review.ID == uuid.Nil {
+		review.ID = uuid.New()
+	}
+	touch(&review.CreatedAt, &review.UpdatedAt)
+	r.store.reviews[review.ID] = *review
+	return nil
+}
+
+func (r *ReviewRepository) ListByBidAuthor(_ context.Context, authorUsername string, limit, offset int) ([]models2.Review, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	reviews := make([]models2.Review, 0)
+	for _, review := range r.store.reviews {
+		if bid, ok := r.store.bids[review.BidID]; ok && bid.CreatorUsername == authorUsername {
+			reviews = append(reviews, review)
+		}
+	}
+
+	sort.Slice(reviews, func(i, j int) bool {
+		return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+	})
+
+	return paginate(reviews, limit, offset), nil
+}
@@ -0,0 +1,244 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+// Store хранит все данные в памяти процесса. Используется для тестов и локальной разработки без Postgres
+type Store struct {
+	mu sync.RWMutex
+	// txMu сериализует транзакции и изменения вне их, чтобы откат снимка не затирал чужие изменения.
+	// Чтение берет только mu и видит незавершенную транзакцию, как при READ UNCOMMITTED
+	txMu sync.Mutex
+	// locks - именованные блокировки TryWithLock, в пределах одного процесса их достаточно
+	locks sync.Map
+
+	tables
+}
+
+type tables struct {
+	employees      map[uuid.UUID]models2.Employee
+	organizations  map[uuid.UUID]models2.Organization
+	responsibles   map[uuid.UUID]models2.OrganizationResponsible
+	tenders        map[uuid.UUID]models2.Tender
+	tenderVersions map[uuid.UUID][]models2.TenderVersion
+	// tenderTransitions хранит историю в порядке записи
+	tenderTransitions map[uuid.UUID][]models2.TenderTransition
+	bids              map[uuid.UUID]models2.Bid
+	bidVersions       map[uuid.UUID][]models2.BidVersion
+	decisions         map[uuid.UUID]models2.BidDecision
+	reviews           map[uuid.UUID]models2.Review
+}
+
+func New() *Store {
+	return &Store{
+		tables: tables{
+			employees:         make(map[uuid.UUID]models2.Employee),
+			organizations:     make(map[uuid.UUID]models2.Organization),
+			responsibles:      make(map[uuid.UUID]models2.OrganizationResponsible),
+			tenders:           make(map[uuid.UUID]models2.Tender),
+			tenderVersions:    make(map[uuid.UUID][]models2.TenderVersion),
+			tenderTransitions: make(map[uuid.UUID][]models2.TenderTransition),
+			bids:              make(map[uuid.UUID]models2.Bid),
+			bidVersions:       make(map[uuid.UUID][]models2.BidVersion),
+			decisions:         make(map[uuid.UUID]models2.BidDecision),
+			reviews:           make(map[uuid.UUID]models2.Review),
+		},
+	}
+}
+
+func (s *Store) Repositories() storage.Repositories {
+	return s.repositories(false)
+}
+
+// repositories возвращает репозитории хранилища. tx - репозитории выдаются внутри WithinTransaction,
+// которая уже держит txMu, поэтому их изменения берут только mu
+func (s *Store) repositories(tx bool) storage.Repositories {
+	return storage.Repositories{
+		Employees:     &EmployeeRepository{store: s},
+		Organizations: &OrganizationRepository{store: s},
+		Tenders:       &TenderRepository{store: s, tx: tx},
+		Bids:          &BidRepository{store: s, tx: tx},
+		Reviews:       &ReviewRepository{store: s, tx: tx},
+	}
+}
+
+// lock берет блокировки для изменения данных и возвращает функцию, которая их снимает.
+// Изменение вне транзакции ждет, пока текущая транзакция завершится, иначе ее откат вернул бы данные из снимка поверх него
+func (s *Store) lock(tx bool) (unlock func()) {
+	if !tx {
+		s.txMu.Lock()
+	}
+	s.mu.Lock()
+
+	return func() {
+		s.mu.Unlock()
+		if !tx {
+			s.txMu.Unlock()
+		}
+	}
+}
+
+// WithinTransaction выполняет fn над этим же хранилищем и восстанавливает снимок данных, если fn вернула ошибку.
+// Репозитории из fn нельзя использовать после ее завершения
+func (s *Store) WithinTransaction(_ context.Context, fn func(repositories storage.Repositories) error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.RLock()
+	snapshot := s.tables.clone()
+	s.mu.RUnlock()
+
+	if err := fn(s.repositories(true)); err != nil {
+		s.mu.Lock()
+		s.tables = snapshot
+		s.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// TryWithLock выполняет fn, если блокировку name не держит другой вызов
+func (s *Store) TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	lock, _ := s.locks.LoadOrStore(name, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	if !mu.TryLock() {
+		return false, nil
+	}
+	defer mu.Unlock()
+
+	return true, fn(ctx)
+}
+
+func (t tables) clone() tables {
+	return tables{
+		employees:         maps.Clone(t.employees),
+		organizations:     maps.Clone(t.organizations),
+		responsibles:      maps.Clone(t.responsibles),
+		tenders:           maps.Clone(t.tenders),
+		tenderVersions:    cloneVersions(t.tenderVersions),
+		tenderTransitions: cloneVersions(t.tenderTransitions),
+		bids:              maps.Clone(t.bids),
+		bidVersions:       cloneVersions(t.bidVersions),
+		decisions:         maps.Clone(t.decisions),
+		reviews:           maps.Clone(t.reviews),
+	}
+}
+
+// cloneVersions копирует и срезы версий, чтобы снимок не делил с данными общие массивы
+func cloneVersions[T any](versions map[uuid.UUID][]T) map[uuid.UUID][]T {
+	cloned := make(map[uuid.UUID][]T, len(versions))
+	for id, items := range versions {
+		cloned[id] = slices.Clone(items)
+	}
+	return cloned
+}
+
+// Seed - справочные данные, которые в Postgres заполняются вручную
+type Seed struct {
+	Employees     []models2.Employee                `json:"employees"`
+	Organizations []models2.Organization            `json:"organizations"`
+	Responsibles  []models2.OrganizationResponsible `json:"responsibles"`
+}
+
+// LoadSeed загружает сотрудников, организации и ответственных из JSON
+func (s *Store) LoadSeed(r io.Reader) error {
+	var seed Seed
+	if err := json.NewDecoder(r).Decode(&seed); err != nil {
+		return fmt.Errorf("decode seed: %w", err)
+	}
+
+	for _, employee := range seed.Employees {
+		if err := s.AddEmployee(employee); err != nil {
+			return err
+		}
+	}
+	for _, organization := range seed.Organizations {
+		s.AddOrganization(organization)
+	}
+	for _, responsible := range seed.Responsibles {
+		if err := s.AddResponsible(responsible); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) AddEmployee(employee models2.Employee) error {
+	defer s.lock(false)()
+
+	for _, existing := range s.employees {
+		if existing.Username == employee.Username {
+			return fmt.Errorf("employee %q already exists", employee.Username)
+		}
+	}
+
+	if employee.ID == uuid.Nil {
+		employee.ID = uuid.New()
+	}
+	touch(&employee.CreatedAt, &employee.UpdatedAt)
+	s.employees[employee.ID] = employee
+	return nil
+}
+
+func (s *Store) AddOrganization(organization models2.Organization) {
+	defer s.lock(false)()
+
+	if organization.ID == uuid.Nil {
+		organization.ID = uuid.New()
+	}
+	touch(&organization.CreatedAt, &organization.UpdatedAt)
+	s.organizations[organization.ID] = organization
+}
+
+func (s *Store) AddResponsible(responsible models2.OrganizationResponsible) error {
+	defer s.lock(false)()
+
+	if _, ok := s.employees[responsible.UserID]; !ok {
+		return fmt.Errorf("employee %s does not exist", responsible.UserID)
+	}
+	if _, ok := s.organizations[responsible.OrganizationID]; !ok {
+		return fmt.Errorf("organization %s does not exist", responsible.OrganizationID)
+	}
+
+	if responsible.ID == uuid.Nil {
+		responsible.ID = uuid.New()
+	}
+	s.responsibles[responsible.ID] = responsible
+	return nil
+}
+
+// touch проставляет время создания и обновления так же, как это делают значения по умолчанию в БД
+func touch(createdAt *time.Time, updatedAt *time.Time) {
+	now := time.Now()
+	if createdAt != nil && createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt != nil {
+		*updatedAt = now
+	}
+}
+
+// paginate применяет limit и offset так же, как gorm: отрицательный limit отключает ограничение
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"slices"
+	"sort"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+type TenderRepository struct {
+	store *Store
+	tx    bool
+}
+
+func (r *TenderRepository) Create(_ context.Context, tender *models2.Tender) error {
+	defer r.store.lock(r.tx)()
+
+	if tender.ID == uuid.Nil {
+		tender.ID = uuid.New()
+	}
+	touch(&tender.CreatedAt, &tender.UpdatedAt)
+	r.store.tenders[tender.ID] = *tender
+	return nil
+}
+
+func (r *TenderRepository) GetByID(_ context.Context, id uuid.UUID) (models2.Tender, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tender, ok := r.store.tenders[id]
+	if !ok {
+		return models2.Tender{}, storage.ErrNotFound
+	}
+	return tender, nil
+}
+
+// GetByIDForUpdate не требует блокировки: транзакции хранилища в памяти выполняются последовательно
+func (r *TenderRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (models2.Tender, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *TenderRepository) List(_ context.Context, filter storage.TenderFilter) ([]models2.Tender, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tenders := make([]models2.Tender, 0)
+	for _, tender := range r.store.tenders {
+		if filter.ServiceType != "" && tender.ServiceType != filter.ServiceType {
+			continue
+		}
+		if filter.Status != "" && tender.Status != filter.Status {
+			continue
+		}
+		if filter.CreatorUsername != "" && tender.CreatorUsername != filter.CreatorUsername {
+			continue
+		}
+		if !filter.DeadlineBefore.IsZero() &&
+			(tender.SubmissionDeadline == nil || tender.SubmissionDeadline.After(filter.DeadlineBefore)) {
+			continue
+		}
+		if filter.OrganizationID != uuid.Nil && tender.OrganizationID != filter.OrganizationID {
+			continue
+		}
+		if filter.PublishScheduled && tender.PublishAt == nil {
+			continue
+		}
+		if !filter.PublishBefore.IsZero() && (tender.PublishAt == nil || tender.PublishAt.After(filter.PublishBefore)) {
+			continue
+		}
+		if !filter.BudgetMatches(tender.Budget) {
+			continue
+		}
+		tenders = append(tenders, tender)
+	}
+
+	sort.Slice(tenders, func(i, j int) bool {
+		return tenders[i].Name < tenders[j].Name
+	})
+
+	return paginate(tenders, filter.Limit, filter.Offset), nil
+}
+
+func (r *TenderRepository) Update(_ context.Context, tender *models2.Tender) error {
+	defer r.store.lock(r.tx)()
+
+	if _, ok := r.store.tenders[tender.ID]; !ok {
+		return storage.ErrNotFound
+	}
+	touch(nil, &tender.UpdatedAt)
+	r.store.tenders[tender.ID] = *tender
+	return nil
+}
+
+func (r *TenderRepository) CreateVersion(_ context.Context, version *models2.TenderVersion) error {
+	defer r.store.lock(r.tx)()
+
+	if version.ID == uuid.Nil {
+		version.ID = uuid.New()
+	}
+	touch(&version.CreatedAt, nil)
+	r.store.tenderVersions[version.TenderID] = append(r.store.tenderVersions[version.TenderID], *version)
+	return nil
+}
+
+func (r *TenderRepository) GetVersion(_ context.Context, tenderID uuid.UUID, version int) (models2.TenderVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, tenderVersion := range r.store.tenderVersions[tenderID] {
+		if tenderVersion.Version == version {
+			return tenderVersion, nil
+		}
+	}
+	return models2.TenderVersion{}, storage.ErrNotFound
+}
+
+func (r *TenderRepository) GetLatestVersion(_ context.Context, tenderID uuid.UUID) (models2.TenderVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	versions := r.store.tenderVersions[tenderID]
+	if len(versions) == 0 {
+		return models2.TenderVersion{}, storage.ErrNotFound
+	}
+
+	latest := versions[0]
+	for _, version := range versions[1:] {
+		if version.Version > latest.Version {
+			latest = version
+		}
+	}
+	return latest, nil
+}
+
+func (r *TenderRepository) ListVersions(_ context.Context, tenderID uuid.UUID) ([]models2.TenderVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	versions := append([]models2.TenderVersion(nil), r.store.tenderVersions[tenderID]...)
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version > versions[j].Version
+	})
+	return versions, nil
+}
+
+func (r *TenderRepository) CreateTransition(_ context.Context, transition *models2.TenderTransition) error {
+	defer r.store.lock(r.tx)()
+
+	if transition.ID == uuid.Nil {
+		transition.ID = uuid.New()
+	}
+	touch(&transition.CreatedAt, nil)
+	r.store.tenderTransitions[transition.TenderID] = append(r.store.tenderTransitions[transition.TenderID], *transition)
+	return nil
+}
+
+func (r *TenderRepository) ListTransitions(_ context.Context, tenderID uuid.UUID, limit, offset int) ([]models2.TenderTransition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	transitions := append([]models2.TenderTransition(nil), r.store.tenderTransitions[tenderID]...)
+	slices.Reverse(transitions)
+	return paginate(transitions, limit, offset), nil
+}
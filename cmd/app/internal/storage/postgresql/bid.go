@@ -0,0 +1,112 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+type BidRepository struct {
+	db *gorm.DB
+}
+
+func NewBidRepository(db *gorm.DB) *BidRepository {
+	return &BidRepository{db: db}
+}
+
+func (r *BidRepository) Create(ctx context.Context, bid *models2.Bid) error {
+	return r.db.WithContext(ctx).Create(bid).Error
+}
+
+func (r *BidRepository) GetByID(ctx context.Context, id uuid.UUID) (models2.Bid, error) {
+	var bid models2.Bid
+	err := r.db.WithContext(ctx).First(&bid, "id = ?", id).Error
+	return bid, convertError(err)
+}
+
+func (r *BidRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (models2.Bid, error) {
+	var bid models2.Bid
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&bid, "id = ?", id).Error
+	return bid, convertError(err)
+}
+
+func (r *BidRepository) filtered(ctx context.Context, filter storage.BidFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models2.Bid{})
+
+	if filter.TenderID != uuid.Nil {
+		query = query.Where("tender_id = ?", filter.TenderID)
+	}
+	if filter.CreatorUsername != "" {
+		query = query.Where("creator_username = ?", filter.CreatorUsername)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	return query
+}
+
+func (r *BidRepository) List(ctx context.Context, filter storage.BidFilter) ([]models2.Bid, error) {
+	var bids []models2.Bid
+	err := r.filtered(ctx, filter).
+		Order("name ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&bids).Error
+	return bids, err
+}
+
+func (r *BidRepository) Count(ctx context.Context, filter storage.BidFilter) (int64, error) {
+	var count int64
+	err := r.filtered(ctx, filter).Count(&count).Error
+	return count, err
+}
+
+func (r *BidRepository) Update(ctx context.Context, bid *models2.Bid) error {
+	return r.db.WithContext(ctx).Save(bid).Error
+}
+
+func (r *BidRepository) CreateVersion(ctx context.Context, version *models2.BidVersion) error {
+	return r.db.WithContext(ctx).Create(version).Error
+}
+
+func (r *BidRepository) GetVersion(ctx context.Context, bidID uuid.UUID, version int) (models2.BidVersion, error) {
+	var bidVersion models2.BidVersion
+	err := r.db.WithContext(ctx).
+		Where("bid_id = ? AND version = ?", bidID, version).
+		First(&bidVersion).Error
+	return bidVersion, convertError(err)
+}
+
+func (r *BidRepository) GetLatestVersion(ctx context.Context, bidID uuid.UUID) (models2.BidVersion, error) {
+	var bidVersion models2.BidVersion
+	err := r.db.WithContext(ctx).
+		Where("bid_id = ?", bidID).
+		Order("version DESC").
+		First(&bidVersion).Error
+	return bidVersion, convertError(err)
+}
+
+func (r *BidRepository) GetDecision(ctx context.Context, bidID, userID uuid.UUID) (models2.BidDecision, error) {
+	var decision models2.BidDecision
+	err := r.db.WithContext(ctx).
+		Where("bid_id = ? AND user_id = ?", bidID, userID).
+		First(&decision).Error
+	return decision, convertError(err)
+}
+
+func (r *BidRepository) SaveDecision(ctx context.Context, decision *models2.BidDecision) error {
+	return r.db.WithContext(ctx).Save(decision).Error
+}
+
+func (r *BidRepository) CountDecisions(ctx context.Context, bidID uuid.UUID, decision models2.BidDecisionType) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models2.BidDecision{}).
+		Where("bid_id = ? AND decision = ?", bidID, decision).
+		Count(&count).Error
+	return count, err
+}
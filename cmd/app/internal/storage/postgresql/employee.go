@@ -0,0 +1,21 @@
+package postgresql
+
+import (
+	"context"
+	"gorm.io/gorm"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+type EmployeeRepository struct {
+	db *gorm.DB
+}
+
+func NewEmployeeRepository(db *gorm.DB) *EmployeeRepository {
+	return &EmployeeRepository{db: db}
+}
+
+func (r *EmployeeRepository) GetByUsername(ctx context.Context, username string) (models2.Employee, error) {
+	var employee models2.Employee
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&employee).Error
+	return employee, convertError(err)
+}
@@ -0,0 +1,32 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// Locker берет advisory-блокировки Postgres, поэтому задачу под одним именем выполняет только один экземпляр приложения
+type Locker struct {
+	db *gorm.DB
+}
+
+func NewLocker(db *gorm.DB) *Locker {
+	return &Locker{db: db}
+}
+
+// TryWithLock выполняет fn, если удалось взять блокировку name без ожидания. Блокировка привязана к транзакции
+// и снимается при ее завершении, даже если соединение оборвется. Сама fn выполняется в своих транзакциях
+func (l *Locker) TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	acquired := false
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", name).Scan(&acquired).Error; err != nil {
+			return fmt.Errorf("acquire advisory lock: %w", err)
+		}
+		if !acquired {
+			return nil
+		}
+		return fn(ctx)
+	})
+	return acquired, err
+}
@@ -0,0 +1,253 @@
+package postgresql
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"gorm.io/gorm"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey - ключ advisory lock, под которым применяются миграции, чтобы несколько реплик не мигрировали одновременно
+const migrationLockKey = 61050001
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+// Migration - пара SQL-файлов <version>_<name>.up.sql и <version>_<name>.down.sql
+type Migration struct {
+	Version int64
+	Name    string
+	up      string
+	down    string
+}
+
+type MigrationStatus struct {
+	Migration
+	// AppliedAt равен nil, если миграция еще не применена
+	AppliedAt *time.Time
+}
+
+// Migrator применяет встроенные в бинарный файл SQL-миграции и хранит их состояние в таблице schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up применяет все непримененные миграции по возрастанию версии, каждую в отдельной транзакции
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	for _, migration := range m.migrations {
+		isApplied := false
+		err := m.withLock(ctx, func(tx *gorm.DB) error {
+			var count int64
+			if err := tx.Raw("SELECT count(*) FROM schema_migrations WHERE version = ?", migration.Version).Scan(&count).Error; err != nil {
+				return fmt.Errorf("check migration: %w", err)
+			}
+			if count > 0 {
+				return nil
+			}
+
+			if err := tx.Exec(migration.up).Error; err != nil {
+				return err
+			}
+			isApplied = true
+			return tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if isApplied {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+// Down откатывает steps последних примененных миграций
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	for range steps {
+		var migration *Migration
+		err := m.withLock(ctx, func(tx *gorm.DB) error {
+			var version int64
+			result := tx.Raw("SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").Scan(&version)
+			if result.Error != nil {
+				return fmt.Errorf("get last migration: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return nil
+			}
+
+			migration = m.find(version)
+			if migration == nil {
+				return fmt.Errorf("migration %d is applied but not found in binary", version)
+			}
+
+			if err := tx.Exec(migration.down).Error; err != nil {
+				return err
+			}
+			return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", version).Error
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("revert migration: %w", err)
+		}
+		if migration == nil {
+			break
+		}
+		reverted = append(reverted, *migration)
+	}
+	return reverted, nil
+}
+
+// Status возвращает все известные миграции с отметкой о применении
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var rows []struct {
+		Version   int64
+		AppliedAt time.Time
+	}
+	err := m.withLock(ctx, func(tx *gorm.DB) error {
+		return tx.Raw("SELECT version, applied_at FROM schema_migrations").Scan(&rows).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get applied migrations: %w", err)
+	}
+
+	appliedAt := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		appliedAt[row.Version] = row.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if at, ok := appliedAt[migration.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending возвращает миграции, которые еще не применены к базе. В отличие от Status не берет блокировку
+// и не создает schema_migrations, поэтому подходит для частых проверок готовности
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	db := m.db.WithContext(ctx)
+
+	var exists bool
+	if err := db.Raw("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists).Error; err != nil {
+		return nil, fmt.Errorf("check schema_migrations: %w", err)
+	}
+
+	var versions []int64
+	if exists {
+		if err := db.Raw("SELECT version FROM schema_migrations").Scan(&versions).Error; err != nil {
+			return nil, fmt.Errorf("get applied migrations: %w", err)
+		}
+	}
+
+	applied := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// withLock выполняет fn в транзакции под advisory lock, предварительно создав таблицу schema_migrations
+func (m *Migrator) withLock(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		if err := tx.Exec(createMigrationsTable).Error; err != nil {
+			return fmt.Errorf("create schema_migrations: %w", err)
+		}
+		return fn(tx)
+	})
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// loadMigrations читает пары up/down файлов из dir и сортирует их по версии
+func loadMigrations(files fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(fileName, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %q", fileName)
+		}
+
+		rawVersion, name, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(rawVersion, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q", fileName)
+		}
+
+		content, err := fs.ReadFile(files, path.Join(dir, fileName))
+		if err != nil {
+			return nil, fmt.Errorf("read migration %q: %w", fileName, err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if direction == "up" {
+			migration.up = string(content)
+		} else {
+			migration.down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" || migration.down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
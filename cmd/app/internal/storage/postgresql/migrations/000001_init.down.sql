@@ -0,0 +1,7 @@
+-- Удаляются только таблицы сервиса. Сотрудники и организации принадлежат стенду и не удаляются.
+DROP TABLE IF EXISTS reviews;
+DROP TABLE IF EXISTS bid_decisions;
+DROP TABLE IF EXISTS bid_versions;
+DROP TABLE IF EXISTS bids;
+DROP TABLE IF EXISTS tender_versions;
+DROP TABLE IF EXISTS tenders;
@@ -0,0 +1,104 @@
+-- Базовая схема. Таблицы employee, organization и organization_responsible в стенде уже созданы,
+-- поэтому все объекты создаются только при их отсутствии.
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'organization_type') THEN
+        CREATE TYPE organization_type AS ENUM ('IE', 'LLC', 'JSC');
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS employee (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    username VARCHAR(50) UNIQUE NOT NULL,
+    first_name VARCHAR(50),
+    last_name VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS organization (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    type organization_type,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS organization_responsible (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES employee(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS tenders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    service_type VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'CREATED',
+    organization_id UUID NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    creator_username TEXT,
+    version BIGINT
+);
+
+CREATE TABLE IF NOT EXISTS tender_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    service_type VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'CREATED',
+    created_at TIMESTAMPTZ,
+    tender_id UUID NOT NULL,
+    version INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS bids (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'Created',
+    tender_id UUID NOT NULL,
+    organization_id UUID NOT NULL,
+    version BIGINT DEFAULT 1,
+    creator_username TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS bid_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL,
+    version BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS bid_decisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bid_decision_bid_user ON bid_decisions (bid_id, user_id);
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id UUID PRIMARY KEY,
+    bid_id UUID,
+    author_username TEXT,
+    organization_id UUID,
+    rating BIGINT,
+    comment TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
@@ -0,0 +1,19 @@
+ALTER TABLE bids DROP CONSTRAINT IF EXISTS chk_bids_version_positive;
+ALTER TABLE tenders DROP CONSTRAINT IF EXISTS chk_tenders_version_positive;
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS fk_reviews_bid;
+ALTER TABLE bid_decisions DROP CONSTRAINT IF EXISTS fk_bid_decisions_user;
+ALTER TABLE bid_decisions DROP CONSTRAINT IF EXISTS fk_bid_decisions_bid;
+ALTER TABLE bid_versions DROP CONSTRAINT IF EXISTS fk_bid_versions_bid;
+ALTER TABLE bids DROP CONSTRAINT IF EXISTS fk_bids_tender;
+ALTER TABLE tender_versions DROP CONSTRAINT IF EXISTS fk_tender_versions_tender;
+ALTER TABLE tenders DROP CONSTRAINT IF EXISTS fk_tenders_organization;
+
+DROP INDEX IF EXISTS idx_organization_responsible_organization_user;
+DROP INDEX IF EXISTS idx_reviews_bid_id;
+DROP INDEX IF EXISTS idx_bid_versions_bid_version;
+DROP INDEX IF EXISTS idx_bids_creator_username;
+DROP INDEX IF EXISTS idx_bids_tender_id;
+DROP INDEX IF EXISTS idx_tender_versions_tender_version;
+DROP INDEX IF EXISTS idx_tenders_status_service_type;
+DROP INDEX IF EXISTS idx_tenders_creator_username;
+DROP INDEX IF EXISTS idx_tenders_organization_id;
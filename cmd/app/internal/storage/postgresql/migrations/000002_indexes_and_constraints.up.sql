@@ -0,0 +1,53 @@
+-- Внешние ключи добавляются как NOT VALID: новые строки проверяются сразу,
+-- а данные, накопленные до миграций, можно проверить отдельно через VALIDATE CONSTRAINT.
+CREATE INDEX IF NOT EXISTS idx_tenders_organization_id ON tenders (organization_id);
+CREATE INDEX IF NOT EXISTS idx_tenders_creator_username ON tenders (creator_username);
+CREATE INDEX IF NOT EXISTS idx_tenders_status_service_type ON tenders (status, service_type);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tender_versions_tender_version ON tender_versions (tender_id, version);
+
+CREATE INDEX IF NOT EXISTS idx_bids_tender_id ON bids (tender_id);
+CREATE INDEX IF NOT EXISTS idx_bids_creator_username ON bids (creator_username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bid_versions_bid_version ON bid_versions (bid_id, version);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_bid_id ON reviews (bid_id);
+CREATE INDEX IF NOT EXISTS idx_organization_responsible_organization_user
+    ON organization_responsible (organization_id, user_id);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_tenders_organization') THEN
+        ALTER TABLE tenders ADD CONSTRAINT fk_tenders_organization
+            FOREIGN KEY (organization_id) REFERENCES organization (id) NOT VALID;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_tender_versions_tender') THEN
+        ALTER TABLE tender_versions ADD CONSTRAINT fk_tender_versions_tender
+            FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE NOT VALID;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_bids_tender') THEN
+        ALTER TABLE bids ADD CONSTRAINT fk_bids_tender
+            FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE NOT VALID;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_bid_versions_bid') THEN
+        ALTER TABLE bid_versions ADD CONSTRAINT fk_bid_versions_bid
+            FOREIGN KEY (bid_id) REFERENCES bids (id) ON DELETE CASCADE NOT VALID;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_bid_decisions_bid') THEN
+        ALTER TABLE bid_decisions ADD CONSTRAINT fk_bid_decisions_bid
+            FOREIGN KEY (bid_id) REFERENCES bids (id) ON DELETE CASCADE NOT VALID;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_bid_decisions_user') THEN
+        ALTER TABLE bid_decisions ADD CONSTRAINT fk_bid_decisions_user
+            FOREIGN KEY (user_id) REFERENCES employee (id) ON DELETE CASCADE NOT VALID;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_reviews_bid') THEN
+        ALTER TABLE reviews ADD CONSTRAINT fk_reviews_bid
+            FOREIGN KEY (bid_id) REFERENCES bids (id) ON DELETE CASCADE NOT VALID;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_tenders_version_positive') THEN
+        ALTER TABLE tenders ADD CONSTRAINT chk_tenders_version_positive CHECK (version >= 1) NOT VALID;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_bids_version_positive') THEN
+        ALTER TABLE bids ADD CONSTRAINT chk_bids_version_positive CHECK (version >= 1) NOT VALID;
+    END IF;
+END
+$$;
@@ -0,0 +1,2 @@
+-- Исходный регистр данных не сохраняется, откатывается только значение по умолчанию
+ALTER TABLE bids ALTER COLUMN status SET DEFAULT 'Created';
@@ -0,0 +1,9 @@
+-- Статусы и решения хранятся в верхнем регистре, написание из спецификации используется только в API.
+-- Строки, записанные до этого в другом регистре (в том числе значением по умолчанию 'Created' у bids), приводятся к нему.
+UPDATE tenders SET status = upper(status) WHERE status <> upper(status);
+UPDATE tender_versions SET status = upper(status) WHERE status <> upper(status);
+UPDATE bids SET status = upper(status) WHERE status <> upper(status);
+UPDATE bid_versions SET status = upper(status) WHERE status <> upper(status);
+UPDATE bid_decisions SET decision = upper(decision) WHERE decision <> upper(decision);
+
+ALTER TABLE bids ALTER COLUMN status SET DEFAULT 'CREATED';
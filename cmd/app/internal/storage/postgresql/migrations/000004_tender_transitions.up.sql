@@ -0,0 +1,12 @@
+-- История смены статусов тендера: кто и когда перевел тендер из одного статуса в другой
+CREATE TABLE IF NOT EXISTS tender_transitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_username VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tender_transitions_tender_created
+    ON tender_transitions (tender_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tenders_published_submission_deadline;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS submission_deadline;
+ALTER TABLE tenders DROP COLUMN IF EXISTS submission_deadline;
@@ -0,0 +1,8 @@
+-- Срок подачи предложений. После него планировщик закрывает опубликованный тендер
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMPTZ;
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMPTZ;
+
+-- Планировщик ищет только опубликованные тендеры со сроком
+CREATE INDEX IF NOT EXISTS idx_tenders_published_submission_deadline
+    ON tenders (submission_deadline)
+    WHERE status = 'PUBLISHED' AND submission_deadline IS NOT NULL;
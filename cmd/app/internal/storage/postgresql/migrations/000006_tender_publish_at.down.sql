@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tenders_created_publish_at;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS author_username;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE tenders DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,9 @@
+-- Время запланированной публикации тендера и автор каждой версии, чтобы отличать изменения планировщика
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS author_username VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Планировщик ищет только созданные тендеры с запланированной публикацией
+CREATE INDEX IF NOT EXISTS idx_tenders_created_publish_at
+    ON tenders (publish_at)
+    WHERE status = 'CREATED' AND publish_at IS NOT NULL;
@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_tenders_budget;
+ALTER TABLE bid_versions
+    DROP COLUMN IF EXISTS price_vat_included,
+    DROP COLUMN IF EXISTS price_currency,
+    DROP COLUMN IF EXISTS price_amount;
+ALTER TABLE bids
+    DROP COLUMN IF EXISTS price_vat_included,
+    DROP COLUMN IF EXISTS price_currency,
+    DROP COLUMN IF EXISTS price_amount;
+ALTER TABLE tender_versions
+    DROP COLUMN IF EXISTS budget_vat_included,
+    DROP COLUMN IF EXISTS budget_currency,
+    DROP COLUMN IF EXISTS budget_amount;
+ALTER TABLE tenders
+    DROP COLUMN IF EXISTS budget_vat_included,
+    DROP COLUMN IF EXISTS budget_currency,
+    DROP COLUMN IF EXISTS budget_amount;
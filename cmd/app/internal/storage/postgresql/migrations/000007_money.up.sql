@@ -0,0 +1,22 @@
+-- Бюджет тендера и цена предложения. Суммы хранятся как NUMERIC, пустая валюта означает, что сумма не указана
+ALTER TABLE tenders
+    ADD COLUMN IF NOT EXISTS budget_amount NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS budget_currency VARCHAR(3) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS budget_vat_included BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tender_versions
+    ADD COLUMN IF NOT EXISTS budget_amount NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS budget_currency VARCHAR(3) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS budget_vat_included BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE bids
+    ADD COLUMN IF NOT EXISTS price_amount NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS price_currency VARCHAR(3) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS price_vat_included BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE bid_versions
+    ADD COLUMN IF NOT EXISTS price_amount NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS price_currency VARCHAR(3) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS price_vat_included BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Фильтр GET /api/tenders по диапазону бюджета
+CREATE INDEX IF NOT EXISTS idx_tenders_budget
+    ON tenders (budget_currency, budget_amount)
+    WHERE budget_currency <> '';
@@ -0,0 +1,46 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (models2.Organization, error) {
+	var organization models2.Organization
+	err := r.db.WithContext(ctx).First(&organization, "id = ?", id).Error
+	return organization, convertError(err)
+}
+
+func (r *OrganizationRepository) IsResponsible(ctx context.Context, organizationID, userID uuid.UUID) (bool, error) {
+	var orgResp models2.OrganizationResponsible
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND organization_id = ?", userID, organizationID).
+		First(&orgResp).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *OrganizationRepository) CountResponsibles(ctx context.Context, organizationID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models2.OrganizationResponsible{}).
+		Where("organization_id = ?", organizationID).
+		Count(&count).Error
+	return count, err
+}
@@ -5,74 +5,92 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
-	"log"
-	"os"
-	models2 "zadanie-6105/cmd/app/internal/models"
+	"time"
 )
 
-const defaultPort = 3306
+const (
+	defaultPort    = 5432
+	defaultSSLMode = "require"
+	// defaultTimeZone - часовой пояс сессии, если POSTGRES_TIMEZONE не задан. Значение по умолчанию задается только здесь
+	defaultTimeZone = "Europe/Moscow"
+)
 
 type Config struct {
-	Host     string `json:"host" yaml:"host"`
-	Port     int    `json:"port" yaml:"port"`
-	User     string `json:"user" yaml:"user"`
-	Password string `json:"password" yaml:"password"`
-	Database string `json:"database" yaml:"database"`
+	Host     string `json:"host" yaml:"host" env:"POSTGRES_HOST"`
+	Port     int    `json:"port" yaml:"port" env:"POSTGRES_PORT"`
+	User     string `json:"user" yaml:"user" env:"POSTGRES_USERNAME"`
+	Password string `json:"password" yaml:"password" env:"POSTGRES_PASSWORD"`
+	Database string `json:"database" yaml:"database" env:"POSTGRES_DATABASE"`
+	SSLMode  string `json:"sslmode" yaml:"sslmode" env:"POSTGRES_SSLMODE"`
+	TimeZone string `json:"timezone" yaml:"timezone" env:"POSTGRES_TIMEZONE"`
+	// MigrateOnStart - применять миграции при старте сервера вместо отдельной команды migrate up
+	MigrateOnStart bool `json:"migrate_on_start" yaml:"migrate_on_start" env:"MIGRATE_ON_START"`
+}
+
+// PoolConfig - настройки пула соединений *sql.DB, нулевые значения оставляют значения database/sql
+type PoolConfig struct {
+	MaxOpenConns    int           `json:"max_open_conns" yaml:"max_open_conns" env:"POSTGRES_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `json:"max_idle_conns" yaml:"max_idle_conns" env:"POSTGRES_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `json:"conn_max_lifetime" yaml:"conn_max_lifetime" env:"POSTGRES_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `json:"conn_max_idle_time" yaml:"conn_max_idle_time" env:"POSTGRES_CONN_MAX_IDLE_TIME"`
 }
 
 func (c *Config) withDefaults() (conf Config) {
 	if c != nil {
 		conf = *c
 	}
-	//TODO Посмотреть, что будет, если не инициализировать строки в структуре
 	if conf.Port == 0 {
 		conf.Port = defaultPort
 	}
+	if conf.SSLMode == "" {
+		conf.SSLMode = defaultSSLMode
+	}
+	if conf.TimeZone == "" {
+		conf.TimeZone = defaultTimeZone
+	}
 	return
 }
 
-// TODO Этот блок убрать и переписать
-type Dbinstance struct {
-	Db *gorm.DB
-}
-
-var DB Dbinstance
-
-func ConnectDb() {
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=require TimeZone=Europe/Moscow",
-		os.Getenv("POSTGRES_HOST"),
-		os.Getenv("POSTGRES_USERNAME"),
-		os.Getenv("POSTGRES_PASSWORD"),
-		os.Getenv("POSTGRES_DATABASE"),
-		os.Getenv("POSTGRES_PORT"),
+// DSN собирает строку подключения в формате key=value
+func (c *Config) DSN() string {
+	conf := c.withDefaults()
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
+		conf.Host,
+		conf.User,
+		conf.Password,
+		conf.Database,
+		conf.Port,
+		conf.SSLMode,
+		conf.TimeZone,
 	)
+}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+// Connect подключается к базе, схему при этом не меняет: миграции применяются командой migrate up
+func Connect(conf Config, pool PoolConfig, queryLogger logger.Interface) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(conf.DSN()), &gorm.Config{
+		Logger: queryLogger,
 	})
-
 	if err != nil {
-		log.Fatal("Failed to connect to database. \n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("connect to database: %w", err)
 	}
 
-	log.Println("connected")
-	db.Logger = logger.Default.LogMode(logger.Info)
-
-	log.Println("running migrations")
-	db.AutoMigrate(
-		&models2.Employee{},
-		&models2.Organization{},
-		&models2.OrganizationResponsible{},
-		&models2.Bid{},
-		&models2.BidVersion{},
-		&models2.Review{},
-		&models2.Tender{},
-		&models2.TenderVersion{},
-	)
-
-	DB = Dbinstance{
-		Db: db,
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get database pool: %w", err)
+	}
+	if pool.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
 	}
+	if pool.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+	if pool.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	}
+
+	return db, nil
 }
@@ -0,0 +1,40 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+func NewRepositories(db *gorm.DB) storage.Repositories {
+	return storage.Repositories{
+		Employees:     NewEmployeeRepository(db),
+		Organizations: NewOrganizationRepository(db),
+		Tenders:       NewTenderRepository(db),
+		Bids:          NewBidRepository(db),
+		Reviews:       NewReviewRepository(db),
+	}
+}
+
+type Transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(repositories storage.Repositories) error) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
+
+// convertError приводит ошибки gorm к ошибкам пакета storage
+func convertError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return storage.ErrNotFound
+	}
+	return err
+}
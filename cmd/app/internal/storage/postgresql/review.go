@@ -0,0 +1,31 @@
+package postgresql
+
+import (
+	"context"
+	"gorm.io/gorm"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+func (r *ReviewRepository) Create(ctx context.Context, review *models2.Review) error {
+	return r.db.WithContext(ctx).Create(review).Error
+}
+
+func (r *ReviewRepository) ListByBidAuthor(ctx context.Context, authorUsername string, limit, offset int) ([]models2.Review, error) {
+	var reviews []models2.Review
+	err := r.db.WithContext(ctx).
+		Joins("JOIN bids ON bids.id = reviews.bid_id").
+		Where("bids.creator_username = ?", authorUsername).
+		Order("reviews.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reviews).Error
+	return reviews, err
+}
@@ -0,0 +1,128 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	models2 "zadanie-6105/cmd/app/internal/models"
+	"zadanie-6105/cmd/app/internal/storage"
+)
+
+type TenderRepository struct {
+	db *gorm.DB
+}
+
+func NewTenderRepository(db *gorm.DB) *TenderRepository {
+	return &TenderRepository{db: db}
+}
+
+func (r *TenderRepository) Create(ctx context.Context, tender *models2.Tender) error {
+	return r.db.WithContext(ctx).Create(tender).Error
+}
+
+func (r *TenderRepository) GetByID(ctx context.Context, id uuid.UUID) (models2.Tender, error) {
+	var tender models2.Tender
+	err := r.db.WithContext(ctx).First(&tender, "id = ?", id).Error
+	return tender, convertError(err)
+}
+
+func (r *TenderRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (models2.Tender, error) {
+	var tender models2.Tender
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&tender, "id = ?", id).Error
+	return tender, convertError(err)
+}
+
+func (r *TenderRepository) List(ctx context.Context, filter storage.TenderFilter) ([]models2.Tender, error) {
+	query := r.db.WithContext(ctx).Model(&models2.Tender{})
+
+	if filter.ServiceType != "" {
+		query = query.Where("service_type = ?", filter.ServiceType)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.CreatorUsername != "" {
+		query = query.Where("creator_username = ?", filter.CreatorUsername)
+	}
+	if !filter.DeadlineBefore.IsZero() {
+		query = query.Where("submission_deadline <= ?", filter.DeadlineBefore)
+	}
+	if filter.OrganizationID != uuid.Nil {
+		query = query.Where("organization_id = ?", filter.OrganizationID)
+	}
+	if filter.PublishScheduled {
+		query = query.Where("publish_at IS NOT NULL")
+	}
+	if !filter.PublishBefore.IsZero() {
+		query = query.Where("publish_at <= ?", filter.PublishBefore)
+	}
+	if filter.BudgetMin != nil || filter.BudgetMax != nil || filter.BudgetCurrency != "" {
+		query = query.Where("budget_currency <> ''")
+	}
+	if filter.BudgetCurrency != "" {
+		query = query.Where("budget_currency = ?", filter.BudgetCurrency)
+	}
+	if filter.BudgetMin != nil {
+		query = query.Where("budget_amount >= ?", *filter.BudgetMin)
+	}
+	if filter.BudgetMax != nil {
+		query = query.Where("budget_amount <= ?", *filter.BudgetMax)
+	}
+
+	var tenders []models2.Tender
+	err := query.Order("name ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&tenders).Error
+	return tenders, err
+}
+
+func (r *TenderRepository) Update(ctx context.Context, tender *models2.Tender) error {
+	return r.db.WithContext(ctx).Save(tender).Error
+}
+
+func (r *TenderRepository) CreateVersion(ctx context.Context, version *models2.TenderVersion) error {
+	return r.db.WithContext(ctx).Create(version).Error
+}
+
+func (r *TenderRepository) GetVersion(ctx context.Context, tenderID uuid.UUID, version int) (models2.TenderVersion, error) {
+	var tenderVersion models2.TenderVersion
+	err := r.db.WithContext(ctx).
+		Where("tender_id = ? AND version = ?", tenderID, version).
+		First(&tenderVersion).Error
+	return tenderVersion, convertError(err)
+}
+
+func (r *TenderRepository) GetLatestVersion(ctx context.Context, tenderID uuid.UUID) (models2.TenderVersion, error) {
+	var tenderVersion models2.TenderVersion
+	err := r.db.WithContext(ctx).
+		Where("tender_id = ?", tenderID).
+		Order("version DESC").
+		First(&tenderVersion).Error
+	return tenderVersion, convertError(err)
+}
+
+func (r *TenderRepository) ListVersions(ctx context.Context, tenderID uuid.UUID) ([]models2.TenderVersion, error) {
+	var versions []models2.TenderVersion
+	err := r.db.WithContext(ctx).
+		Where("tender_id = ?", tenderID).
+		Order("version DESC").
+		Find(&versions).Error
+	return versions, err
+}
+
+func (r *TenderRepository) CreateTransition(ctx context.Context, transition *models2.TenderTransition) error {
+	return r.db.WithContext(ctx).Create(transition).Error
+}
+
+func (r *TenderRepository) ListTransitions(ctx context.Context, tenderID uuid.UUID, limit, offset int) ([]models2.TenderTransition, error) {
+	var transitions []models2.TenderTransition
+	err := r.db.WithContext(ctx).
+		Where("tender_id = ?", tenderID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&transitions).Error
+	return transitions, err
+}
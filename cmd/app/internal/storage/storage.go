@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"time"
+	models2 "zadanie-6105/cmd/app/internal/models"
+)
+
+// ErrNotFound возвращается репозиториями, если запись не найдена
+var ErrNotFound = errors.New("record not found")
+
+// Repositories объединяет репозитории одного хранилища
+type Repositories struct {
+	Employees     EmployeeRepository
+	Organizations OrganizationRepository
+	Tenders       TenderRepository
+	Bids          BidRepository
+	Reviews       ReviewRepository
+}
+
+// Transactor выполняет fn в одной транзакции над репозиториями, переданными в fn.
+// Если fn возвращает ошибку, все изменения откатываются
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(repositories Repositories) error) error
+}
+
+// Locker выполняет fn под именованной блокировкой, общей для всех экземпляров приложения.
+// Если блокировку держит другой экземпляр, fn не вызывается и возвращается false
+type Locker interface {
+	TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+}
+
+// TenderFilter и BidFilter с отрицательным Limit возвращают записи без ограничения
+type TenderFilter struct {
+	ServiceType     string
+	Status          models2.TenderStatusType
+	CreatorUsername string
+	// DeadlineBefore, если задан, оставляет тендеры, срок подачи предложений которых наступил не позже него
+	DeadlineBefore time.Time
+	// OrganizationID, если задан, оставляет тендеры организации
+	OrganizationID uuid.UUID
+	// PublishScheduled оставляет тендеры с запланированной публикацией
+	PublishScheduled bool
+	// PublishBefore, если задан, оставляет тендеры, время публикации которых наступило не позже него
+	PublishBefore time.Time
+	// BudgetMin и BudgetMax, если заданы, оставляют тендеры с бюджетом в этих границах включительно,
+	// BudgetCurrency - с бюджетом в этой валюте. Тендеры без бюджета под такие фильтры не подходят.
+	// Границы сравниваются без пересчета валют, поэтому задаются вместе с BudgetCurrency
+	BudgetMin      *decimal.Decimal
+	BudgetMax      *decimal.Decimal
+	BudgetCurrency string
+	Limit          int
+	Offset         int
+}
+
+// BudgetMatches проверяет бюджет тендера по фильтрам BudgetMin, BudgetMax и BudgetCurrency
+func (filter TenderFilter) BudgetMatches(budget models2.Money) bool {
+	if filter.BudgetMin == nil && filter.BudgetMax == nil && filter.BudgetCurrency == "" {
+		return true
+	}
+	if budget.IsZero() {
+		return false
+	}
+	if filter.BudgetCurrency != "" && budget.Currency != filter.BudgetCurrency {
+		return false
+	}
+	if filter.BudgetMin != nil && budget.Amount.LessThan(*filter.BudgetMin) {
+		return false
+	}
+	if filter.BudgetMax != nil && budget.Amount.GreaterThan(*filter.BudgetMax) {
+		return false
+	}
+	return true
+}
+
+type BidFilter struct {
+	TenderID        uuid.UUID
+	CreatorUsername string
+	Status          models2.BidStatusType
+	Limit           int
+	Offset          int
+}
+
+type EmployeeRepository interface {
+	GetByUsername(ctx context.Context, username string) (models2.Employee, error)
+}
+
+type OrganizationRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (models2.Organization, error)
+	IsResponsible(ctx context.Context, organizationID, userID uuid.UUID) (bool, error)
+	CountResponsibles(ctx context.Context, organizationID uuid.UUID) (int64, error)
+}
+
+type TenderRepository interface {
+	Create(ctx context.Context, tender *models2.Tender) error
+	GetByID(ctx context.Context, id uuid.UUID) (models2.Tender, error)
+	// GetByIDForUpdate блокирует тендер до конца транзакции, чтобы конкурентные изменения не перетирали друг друга
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (models2.Tender, error)
+	// List возвращает тендеры, отсортированные по названию
+	List(ctx context.Context, filter TenderFilter) ([]models2.Tender, error)
+	Update(ctx context.Context, tender *models2.Tender) error
+
+	CreateVersion(ctx context.Context, version *models2.TenderVersion) error
+	GetVersion(ctx context.Context, tenderID uuid.UUID, version int) (models2.TenderVersion, error)
+	GetLatestVersion(ctx context.Context, tenderID uuid.UUID) (models2.TenderVersion, error)
+	// ListVersions возвращает версии тендера, начиная с последней
+	ListVersions(ctx context.Context, tenderID uuid.UUID) ([]models2.TenderVersion, error)
+
+	CreateTransition(ctx context.Context, transition *models2.TenderTransition) error
+	// ListTransitions возвращает историю смены статусов тендера, начиная с последних переходов
+	ListTransitions(ctx context.Context, tenderID uuid.UUID, limit, offset int) ([]models2.TenderTransition, error)
+}
+
+type BidRepository interface {
+	Create(ctx context.Context, bid *models2.Bid) error
+	GetByID(ctx context.Context, id uuid.UUID) (models2.Bid, error)
+	// GetByIDForUpdate блокирует предложение до конца транзакции, чтобы конкурентные изменения не перетирали друг друга
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (models2.Bid, error)
+	// List возвращает предложения, отсортированные по названию
+	List(ctx context.Context, filter BidFilter) ([]models2.Bid, error)
+	Count(ctx context.Context, filter BidFilter) (int64, error)
+	Update(ctx context.Context, bid *models2.Bid) error
+
+	CreateVersion(ctx context.Context, version *models2.BidVersion) error
+	GetVersion(ctx context.Context, bidID uuid.UUID, version int) (models2.BidVersion, error)
+	GetLatestVersion(ctx context.Context, bidID uuid.UUID) (models2.BidVersion, error)
+
+	GetDecision(ctx context.Context, bidID, userID uuid.UUID) (models2.BidDecision, error)
+	SaveDecision(ctx context.Context, decision *models2.BidDecision) error
+	CountDecisions(ctx context.Context, bidID uuid.UUID, decision models2.BidDecisionType) (int64, error)
+}
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *models2.Review) error
+	// ListByBidAuthor возвращает отзывы на предложения автора, начиная с новых
+	ListByBidAuthor(ctx context.Context, authorUsername string, limit, offset int) ([]models2.Review, error)
+}
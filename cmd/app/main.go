@@ -1,9 +1,21 @@
 package main
 
 import (
+	"os"
+	"zadanie-6105/cmd/app/internal/logging"
 	"zadanie-6105/cmd/app/internal/servers/http"
 )
 
 func main() {
-	http.Run()
+	conf := loadConfig()
+	logging.Setup(conf.Log.Format, conf.Log.SlogLevel())
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(conf, os.Args[2:]); err != nil {
+			logging.Fatal("Ошибка миграции", err)
+		}
+		return
+	}
+
+	http.Run(conf)
 }
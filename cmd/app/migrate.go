@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"zadanie-6105/cmd/app/internal/config"
+	"zadanie-6105/cmd/app/internal/logging"
+	"zadanie-6105/cmd/app/internal/storage/postgresql"
+)
+
+const migrateUsage = "использование: migrate up | down [количество] | status"
+
+// runMigrate выполняет подкоманды migrate up|down|status над базой из конфигурации
+func runMigrate(conf config.Config, args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := postgresql.Connect(conf.Database, conf.Pool, logging.NewGormLogger(conf.Log.SQLLogLevel(), conf.Log.SQLSlowThreshold))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		sqlDB, dbErr := db.DB()
+		if dbErr == nil {
+			dbErr = sqlDB.Close()
+		}
+		if dbErr != nil {
+			err = errors.Join(err, fmt.Errorf("close database: %w", dbErr))
+		}
+	}()
+
+	migrator, err := postgresql.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			slog.Info("Применена миграция", "version", migration.Version, "name", migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			slog.Info("Новых миграций нет")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("некорректное количество миграций для отката: %s", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			slog.Info("Откачена миграция", "version", migration.Version, "name", migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			slog.Info("Нет примененных миграций")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "не применена"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%06d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}